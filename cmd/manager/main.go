@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"github.com/3scale/apicast-operator/pkg/apis"
 	"github.com/3scale/apicast-operator/pkg/controller"
+	apicastcontroller "github.com/3scale/apicast-operator/pkg/controller/apicast"
+	"github.com/3scale/apicast-operator/pkg/webhook"
 
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	"github.com/operator-framework/operator-sdk/pkg/leader"
@@ -31,6 +35,13 @@ var (
 	metricsHost       = "0.0.0.0"
 	metricsPort int32 = 8383
 )
+
+// Change below variables to serve the admission webhooks on a different port
+// or to provision their certificate in a different directory.
+var (
+	webhookPort    int32 = 9443
+	webhookCertDir       = "/tmp/apicast-operator-webhook-certs"
+)
 var log = logf.Log.WithName("cmd")
 
 func printVersion() {
@@ -48,6 +59,14 @@ func main() {
 	// controller-runtime)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 
+	enableWebhooks := pflag.Bool("enable-webhooks", false, "Serve the admission webhooks that default and validate the APIcast resources. Requires permissions to manage webhook configurations")
+
+	fieldManager := pflag.String("field-manager", apicastcontroller.DefaultFieldManager, "Name identifying the operator as the manager of the fields it writes to the objects, i.e. in the output of kubectl get --show-managed-fields")
+
+	resyncPeriod := pflag.Duration("resync-period", 10*time.Hour, "Period every APIcast resource is reconciled again, even if neither it nor its resources have changed, reverting the changes to the resources not notified to the operator")
+
+	adminPortalCredentialsNamespaces := pflag.StringSlice("admin-portal-credentials-namespaces", nil, "Comma separated namespaces from which the APIcast resources of other namespaces can reference admin portal credentials secrets. By default only the secrets of the namespace of the APIcast resource can be referenced")
+
 	pflag.Parse()
 
 	// Use a zap logr.Logger implementation. If none of the zap
@@ -62,6 +81,16 @@ func main() {
 
 	printVersion()
 
+	if *resyncPeriod <= 0 {
+		log.Error(fmt.Errorf("invalid resync period %s", *resyncPeriod), "")
+		os.Exit(1)
+	}
+
+	if *fieldManager == "" || strings.Contains(*fieldManager, "/") {
+		log.Error(fmt.Errorf("invalid field manager name %q", *fieldManager), "")
+		os.Exit(1)
+	}
+
 	namespace, err := k8sutil.GetWatchNamespace()
 	if err != nil {
 		log.Error(err, "Failed to get watch namespace")
@@ -74,6 +103,7 @@ func main() {
 		log.Error(err, "")
 		os.Exit(1)
 	}
+	cfg.UserAgent = apicastcontroller.UserAgent(*fieldManager)
 
 	ctx := context.TODO()
 
@@ -89,6 +119,7 @@ func main() {
 		Namespace:          namespace,
 		MapperProvider:     restmapper.NewDynamicRESTMapper,
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
+		SyncPeriod:         resyncPeriod,
 	})
 	if err != nil {
 		log.Error(err, "")
@@ -104,11 +135,19 @@ func main() {
 	}
 
 	// Setup all Controllers
+	apicastcontroller.AdminPortalCredentialsNamespaces = *adminPortalCredentialsNamespaces
 	if err := controller.AddToManager(mgr); err != nil {
 		log.Error(err, "")
 		os.Exit(1)
 	}
 
+	if *enableWebhooks {
+		if err := addWebhooks(mgr); err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
+	}
+
 	// Create Service object to expose the metrics port.
 	_, err = metrics.ExposeMetricsPort(ctx, metricsPort)
 	if err != nil {
@@ -123,3 +162,24 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// addWebhooks adds the admission webhook server to the manager. It is
+// exposed by a Service selecting the operator pods
+func addWebhooks(mgr manager.Manager) error {
+	operatorNamespace, err := k8sutil.GetOperatorNamespace()
+	if err != nil {
+		return err
+	}
+
+	operatorName, err := k8sutil.GetOperatorName()
+	if err != nil {
+		return err
+	}
+
+	return webhook.AddToManager(mgr, webhook.Options{
+		Port:            webhookPort,
+		CertDir:         webhookCertDir,
+		Namespace:       operatorNamespace,
+		ServiceSelector: map[string]string{"name": operatorName},
+	})
+}
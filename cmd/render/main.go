@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/3scale/apicast-operator/pkg/apis"
+	appsv1alpha1 "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1"
+	apicastcontroller "github.com/3scale/apicast-operator/pkg/controller/apicast"
+	"github.com/ghodss/yaml"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// render prints the manifests the operator would create for an APIcast
+// custom resource, without connecting to a cluster. The secrets referenced
+// by the custom resource are read from files
+func main() {
+	var crFile string
+	var secretFiles stringSliceFlag
+	flag.StringVar(&crFile, "f", "", "Path to the APIcast custom resource YAML file")
+	flag.Var(&secretFiles, "secret", "Path to a YAML file with a Secret referenced by the APIcast custom resource. Can be repeated")
+	flag.Parse()
+
+	if crFile == "" {
+		fmt.Fprintln(os.Stderr, "Missing required flag -f")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := render(os.Stdout, crFile, secretFiles)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func render(w io.Writer, crFile string, secretFiles []string) error {
+	cr := &appsv1alpha1.APIcast{}
+	err := readYAMLFile(crFile, cr)
+	if err != nil {
+		return err
+	}
+	if cr.Namespace == "" {
+		cr.Namespace = "default"
+	}
+	cr.Spec.SetDefaults()
+	if errs := cr.Validate(); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
+	objects := []runtime.Object{}
+	for _, secretFile := range secretFiles {
+		secret := &v1.Secret{}
+		err = readYAMLFile(secretFile, secret)
+		if err != nil {
+			return err
+		}
+		if secret.Namespace == "" {
+			secret.Namespace = cr.Namespace
+		}
+		// The API server merges stringData into data when secrets are
+		// created, and the operator only reads data
+		for key, value := range secret.StringData {
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			secret.Data[key] = []byte(value)
+		}
+		objects = append(objects, secret)
+	}
+
+	err = apis.AddToScheme(scheme.Scheme)
+	if err != nil {
+		return err
+	}
+
+	client := fake.NewFakeClientWithScheme(scheme.Scheme, objects...)
+	baseReconciler := apicastcontroller.NewBaseReconciler(client, client, scheme.Scheme, logf.Log, &record.FakeRecorder{})
+	logicReconciler := apicastcontroller.NewAPIcastLogicReconciler(baseReconciler, cr)
+	apicast, err := logicReconciler.APIcastFromCRContents()
+	if err != nil {
+		return err
+	}
+
+	for _, object := range apicast.Render() {
+		out, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "---\n%s", out)
+	}
+
+	return nil
+}
+
+func readYAMLFile(path string, obj interface{}) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	err = yaml.Unmarshal(content, obj)
+	if err != nil {
+		return fmt.Errorf("Error parsing '%s': %s", path, err)
+	}
+	return nil
+}
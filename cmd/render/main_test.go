@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testAPIcast = `apiVersion: apps.3scale.net/v1alpha1
+kind: APIcast
+metadata:
+  name: example-apicast
+spec:
+  embeddedConfigurationSecretRef:
+    name: apicast-config
+`
+
+const testConfigSecret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: apicast-config
+stringData:
+  config.json: '{"services":[]}'
+`
+
+// writeTestFiles writes the files in a temporary directory, returning their
+// paths in the same order
+func writeTestFiles(t *testing.T, dir string, contents ...string) []string {
+	paths := []string{}
+	for idx, content := range contents {
+		path := filepath.Join(dir, fmt.Sprintf("%d.yaml", idx))
+		err := ioutil.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		name          string
+		cr            string
+		secrets       []string
+		expectedKinds []string
+		expectedError string
+	}{
+		{"embedded configuration", testAPIcast, []string{testConfigSecret}, []string{"kind: Deployment", "kind: Service"}, ""},
+		{"missing secret", testAPIcast, nil, nil, "apicast-config"},
+		{"invalid configuration", testAPIcast, []string{strings.Replace(testConfigSecret, `'{"services":[]}'`, `'{"services":['`, 1)}, nil, "Invalid JSON"},
+		{"invalid spec", testAPIcast + "  replicas: -1\n", []string{testConfigSecret}, nil, "spec.replicas"},
+		{"invalid YAML", "spec: [", nil, nil, "Error parsing"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "render")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			files := writeTestFiles(t, dir, append([]string{tc.cr}, tc.secrets...)...)
+
+			out := &bytes.Buffer{}
+			err = render(out, files[0], files[1:])
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, kind := range tc.expectedKinds {
+				assert.Contains(t, out.String(), kind)
+			}
+			// The namespace is defaulted when the custom resource has none
+			assert.Contains(t, out.String(), "namespace: default")
+		})
+	}
+}
+
+func TestRenderMissingFile(t *testing.T) {
+	err := render(&bytes.Buffer{}, filepath.Join(os.TempDir(), "missing-apicast.yaml"), nil)
+	assert.Error(t, err)
+}
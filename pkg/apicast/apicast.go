@@ -1,14 +1,20 @@
 package apicast
 
 import (
+	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -17,45 +23,279 @@ type APIcast struct {
 	DeploymentName                   string
 	ServiceName                      string
 	Replicas                         int32
+	RevisionHistoryLimit             *int32
+	MinReadySeconds                  int32
+	ProgressDeadlineSeconds          *int32
+	DaemonSetWorkload                bool
 	AppLabel                         string
 	AdditionalAnnotations            map[string]string
+	DeploymentAnnotations            map[string]string
+	ServiceAnnotations               map[string]string
+	PodLabels                        map[string]string
 	ServiceAccountName               string
+	AutomountServiceAccountToken     *bool
+	ManagedServiceAccount            bool
+	ManagedService                   bool
+	ManagedIngress                   bool
 	Image                            string
+	Command                          []string
+	Args                             []string
 	ExposedHost                      ExposedHost
 	OwnerReference                   *metav1.OwnerReference
 	AdminPortalCredentialsSecretName *string
 
-	DeploymentEnvironment          *string
-	DNSResolverAddress             *string
-	EnabledServices                []string
-	ConfigurationLoadMode          *string
-	LogLevel                       *string
-	PathRoutingEnabled             *bool
-	ResponseCodesIncluded          *bool
-	CacheConfigurationSeconds      *int64
-	ManagementAPIScope             *string
-	OpenSSLPeerVerificationEnabled *bool
-	GatewayConfigurationSecretName *string
+	DeploymentEnvironment           *string
+	DNSResolverAddress              *string
+	EnabledServices                 []string
+	ServicesFilterByURL             *string
+	ConfigurationLoadMode           *string
+	LogLevel                        *string
+	OIDCLogLevel                    *string
+	PathRoutingEnabled              *bool
+	PathRoutingOnly                 *bool
+	ResponseCodesIncluded           *bool
+	CacheConfigurationSeconds       *int64
+	CacheConfigurationJitterSeconds *int64
+	ManagementAPIScope              *string
+	BackendCacheHandler             *string
+	OpenSSLPeerVerificationEnabled  *bool
+	LazyLoadServices                *bool
+	ExtendedMetrics                 *bool
+	UpstreamKeepaliveRequests       *int32
+	UpstreamRetryCases              []string
+	UpstreamTLSSecretName           *string
+	ReportingThreads                *int32
+	BatcherSharedMemorySizeMiB      *int32
+	GatewayConfigurationSecretName  *string
+	GatewayConfigurationClaimName   *string
+	GatewayConfigurationClaimPath   string
+	RemoteConfigurationURL          *string
+	RemoteConfigurationSecretName   *string
+	HTTPProxy                       *string
+	HTTPSProxy                      *string
+	NoProxy                         *string
+	HTTPSPort                       *int32
+	HTTPSVerifyDepth                *int64
+	TerminationGracePeriodSeconds   *int64
+	AccessLogFile                   *string
+	ErrorLogFile                    *string
+	LogForwarder                    *LogForwarder
+	PreStopCommand                  []string
+	HotReloadImage                  *string
+	ProxyPort                       Port
+	ManagementPort                  Port
+	MetricsPort                     Port
+	MetricsServicePortEnabled       bool
+	ManagementServiceEnabled        bool
+	ManagementPortHidden            bool
+	ServiceType                     *v1.ServiceType
+	ServiceExternalTrafficPolicy    *v1.ServiceExternalTrafficPolicyType
+	ServiceSessionAffinity          *v1.ServiceAffinity
+	PriorityClassName               *string
+	DNSPolicy                       *v1.DNSPolicy
+	DNSConfig                       *v1.PodDNSConfig
+	InitContainers                  []InitContainer
+	ReadinessProbeTiming            *ProbeTiming
+	ReadinessProbePort              *int32
+	LivenessProbeTiming             *ProbeTiming
+	LivenessProbePort               *int32
+	AdditionalVolumes               []v1.Volume
+	CustomPolicies                  []CustomPolicy
+	PolicyLoadPath                  []string
+	AdditionalVolumeMounts          []v1.VolumeMount
+	NetworkPolicyRules              *NetworkPolicyRules
+	Standby                         bool
+	SecureMetrics                   *SecureMetrics
+}
+
+// SecureMetrics defines the sidecar serving the metrics over TLS and/or with
+// basic authentication
+type SecureMetrics struct {
+	Image               string
+	TLSSecretName       *string
+	BasicAuthSecretName *string
+}
+
+// ProbeTiming defines the timing settings of a probe
+type ProbeTiming struct {
+	InitialDelaySeconds int32
+	TimeoutSeconds      int32
+	PeriodSeconds       int32
+	SuccessThreshold    int32
+	FailureThreshold    int32
+}
+
+type InitContainer struct {
+	Name    string
+	Image   string
+	Command []string
+	Args    []string
+}
+
+// CustomPolicy defines a custom policy whose files are in a secret or in a
+// ConfigMap
+type CustomPolicy struct {
+	Name          string
+	Version       string
+	SecretName    *string
+	ConfigMapName *string
+}
+
+// Port defines the name and the Service port number of a gateway port
+type Port struct {
+	Name string
+	Port int32
 }
 
 type ExposedHost struct {
-	Host string
-	TLS  []extensions.IngressTLS
+	Host                     string
+	AdditionalHosts          []string
+	Path                     string
+	Annotations              map[string]string
+	TLS                      []extensions.IngressTLS
+	CertManagerClusterIssuer *string
+}
+
+// Hosts returns all the exposed hosts, starting with the main one
+func (e *ExposedHost) Hosts() []string {
+	return append([]string{e.Host}, e.AdditionalHosts...)
+}
+
+// NetworkPolicyRules defines the sources and the destinations allowed by the
+// NetworkPolicy of the gateway pods
+type NetworkPolicyRules struct {
+	ProxyFrom      []networkingv1.NetworkPolicyPeer
+	ManagementFrom []networkingv1.NetworkPolicyPeer
+	Egress         []networkingv1.NetworkPolicyEgressRule
+}
+
+type LogForwarder struct {
+	Image   string
+	Command []string
+	Args    []string
 }
 
 const (
 	AdminPortalURLAttributeName = "AdminPortalURL"
 )
 
+const (
+	ProxyContainerPort      int32 = 8080
+	ManagementContainerPort int32 = 8090
+	MetricsContainerPort    int32 = 9421
+)
+
+const (
+	DefaultProxyPortName      = "proxy"
+	DefaultManagementPortName = "management"
+	DefaultMetricsPortName    = "metrics"
+)
+
+// DefaultReadinessProbeTiming is the timing of the readiness probe when it
+// is not customized. SuccessThreshold and FailureThreshold are set to the
+// Kubernetes defaults
+var DefaultReadinessProbeTiming = ProbeTiming{
+	InitialDelaySeconds: 15,
+	TimeoutSeconds:      5,
+	PeriodSeconds:       30,
+	SuccessThreshold:    1,
+	FailureThreshold:    3,
+}
+
+// DefaultLivenessProbeTiming is the timing of the liveness probe when it is
+// not customized. SuccessThreshold and FailureThreshold are set to the
+// Kubernetes defaults
+var DefaultLivenessProbeTiming = ProbeTiming{
+	InitialDelaySeconds: 10,
+	TimeoutSeconds:      5,
+	PeriodSeconds:       10,
+	SuccessThreshold:    1,
+	FailureThreshold:    3,
+}
+
+const (
+	DefaultPreStopSleepSeconds int64 = 5
+)
+
+const (
+	CertificateAPIVersion = "cert-manager.io/v1"
+	CertificateKind       = "Certificate"
+)
+
 const (
 	EmbeddedConfigurationMountPath  = "/tmp/gateway-configuration-volume"
 	EmbeddedConfigurationVolumeName = "gateway-configuration-volume"
 	EmbeddedConfigurationSecretKey  = "config.json"
 )
 
+const (
+	UpstreamTLSMountPath  = "/var/run/secrets/apicast/upstream-tls"
+	UpstreamTLSVolumeName = "upstream-tls-volume"
+)
+
+const (
+	AccessLogsMountPath       = "/var/log/apicast"
+	AccessLogsVolumeName      = "access-logs-volume"
+	DefaultAccessLogFile      = AccessLogsMountPath + "/access.log"
+	DefaultErrorLogFile       = AccessLogsMountPath + "/error.log"
+	LogForwarderContainerName = "log-forwarder"
+)
+
+const (
+	HotReloadContainerName = "hot-reload"
+)
+
+const (
+	SecureMetricsContainerName             = "secure-metrics"
+	SecureMetricsContainerPort       int32 = 9443
+	SecureMetricsTLSMountPath              = "/var/run/secrets/apicast/metrics-tls"
+	SecureMetricsTLSVolumeName             = "metrics-tls-volume"
+	SecureMetricsBasicAuthMountPath        = "/var/run/secrets/apicast/metrics-basic-auth"
+	SecureMetricsBasicAuthVolumeName       = "metrics-basic-auth-volume"
+)
+
+// StandbyLabel is added to the selector of the Service of the standby
+// gateways. The gateway pods never have it, so the Service has no endpoints
+const StandbyLabel = "apicast.apps.3scale.net/standby"
+
+const (
+	CustomPoliciesMountPath      = "/opt/app-root/src/policies"
+	CustomPolicyVolumeNamePrefix = "custom-policy-"
+)
+
+// ReservedVolumeNames are the names of the volumes managed by the operator.
+// They cannot be used by additional volumes, neither the names of the custom
+// policy volumes
+var ReservedVolumeNames = []string{
+	EmbeddedConfigurationVolumeName,
+	AccessLogsVolumeName,
+	UpstreamTLSVolumeName,
+	SecureMetricsTLSVolumeName,
+	SecureMetricsBasicAuthVolumeName,
+}
+
+// IsReservedVolumeName returns whether the volume name is used by the
+// volumes managed by the operator
+func IsReservedVolumeName(name string) bool {
+	for _, reservedName := range ReservedVolumeNames {
+		if name == reservedName {
+			return true
+		}
+	}
+	return strings.HasPrefix(name, CustomPolicyVolumeNamePrefix)
+}
+
+// SidecarContainerNames are the names of the sidecar containers the operator
+// can add to the APIcast pods
+var SidecarContainerNames = []string{
+	LogForwarderContainerName,
+	HotReloadContainerName,
+	SecureMetricsContainerName,
+}
+
 func (a *APIcast) deploymentVolumeMounts() []v1.VolumeMount {
 	var volumeMounts []v1.VolumeMount
-	if a.GatewayConfigurationSecretName != nil {
+	if a.GatewayConfigurationSecretName != nil || a.GatewayConfigurationClaimName != nil {
 		volumeMounts = append(volumeMounts, v1.VolumeMount{
 			Name:      EmbeddedConfigurationVolumeName,
 			MountPath: EmbeddedConfigurationMountPath,
@@ -63,6 +303,31 @@ func (a *APIcast) deploymentVolumeMounts() []v1.VolumeMount {
 		})
 	}
 
+	if a.UpstreamTLSSecretName != nil {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      UpstreamTLSVolumeName,
+			MountPath: UpstreamTLSMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if a.logsVolumeEnabled() {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      AccessLogsVolumeName,
+			MountPath: AccessLogsMountPath,
+		})
+	}
+
+	for idx, customPolicy := range a.CustomPolicies {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      customPolicyVolumeName(idx),
+			MountPath: path.Join(CustomPoliciesMountPath, customPolicy.Name, customPolicy.Version),
+			ReadOnly:  true,
+		})
+	}
+
+	volumeMounts = append(volumeMounts, a.AdditionalVolumeMounts...)
+
 	return volumeMounts
 }
 
@@ -85,9 +350,104 @@ func (a *APIcast) deploymentVolumes() []v1.Volume {
 		})
 	}
 
+	if a.GatewayConfigurationClaimName != nil {
+		volumes = append(volumes, v1.Volume{
+			Name: EmbeddedConfigurationVolumeName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: *a.GatewayConfigurationClaimName,
+					ReadOnly:  true,
+				},
+			},
+		})
+	}
+
+	if a.UpstreamTLSSecretName != nil {
+		volumes = append(volumes, v1.Volume{
+			Name: UpstreamTLSVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: *a.UpstreamTLSSecretName,
+					Items: []v1.KeyToPath{
+						v1.KeyToPath{
+							Key:  v1.TLSCertKey,
+							Path: v1.TLSCertKey,
+						},
+						v1.KeyToPath{
+							Key:  v1.TLSPrivateKeyKey,
+							Path: v1.TLSPrivateKeyKey,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	if a.logsVolumeEnabled() {
+		volumes = append(volumes, v1.Volume{
+			Name: AccessLogsVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
+	for idx, customPolicy := range a.CustomPolicies {
+		// The default mode is set explicitly, as the API server defaults it
+		defaultMode := int32(0644)
+		volume := v1.Volume{Name: customPolicyVolumeName(idx)}
+		if customPolicy.SecretName != nil {
+			volume.Secret = &v1.SecretVolumeSource{
+				SecretName:  *customPolicy.SecretName,
+				DefaultMode: &defaultMode,
+			}
+		} else if customPolicy.ConfigMapName != nil {
+			volume.ConfigMap = &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: *customPolicy.ConfigMapName},
+				DefaultMode:          &defaultMode,
+			}
+		}
+		volumes = append(volumes, volume)
+	}
+
+	if a.SecureMetrics != nil && a.SecureMetrics.TLSSecretName != nil {
+		volumes = append(volumes, secretVolume(SecureMetricsTLSVolumeName, *a.SecureMetrics.TLSSecretName, v1.TLSCertKey, v1.TLSPrivateKeyKey))
+	}
+
+	if a.SecureMetrics != nil && a.SecureMetrics.BasicAuthSecretName != nil {
+		volumes = append(volumes, secretVolume(SecureMetricsBasicAuthVolumeName, *a.SecureMetrics.BasicAuthSecretName, v1.BasicAuthUsernameKey, v1.BasicAuthPasswordKey))
+	}
+
+	volumes = append(volumes, a.AdditionalVolumes...)
+
 	return volumes
 }
 
+// secretVolume returns the volume with the given keys of the secret. The
+// default mode is set explicitly, as the API server defaults it
+func secretVolume(name, secretName string, keys ...string) v1.Volume {
+	defaultMode := int32(0644)
+	items := []v1.KeyToPath{}
+	for _, key := range keys {
+		items = append(items, v1.KeyToPath{Key: key, Path: key})
+	}
+
+	return v1.Volume{
+		Name: name,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName:  secretName,
+				Items:       items,
+				DefaultMode: &defaultMode,
+			},
+		},
+	}
+}
+
+func customPolicyVolumeName(idx int) string {
+	return fmt.Sprintf("%s%d", CustomPolicyVolumeNamePrefix, idx)
+}
+
 func (a *APIcast) envVarFromValue(name string, value string) v1.EnvVar {
 	return v1.EnvVar{
 		Name:  name,
@@ -131,6 +491,10 @@ func (a *APIcast) deploymentEnv() []v1.EnvVar {
 		}
 	}
 
+	if a.ServicesFilterByURL != nil {
+		env = append(env, a.envVarFromValue("APICAST_SERVICES_FILTER_BY_URL", *a.ServicesFilterByURL))
+	}
+
 	if a.ConfigurationLoadMode != nil {
 		env = append(env, a.envVarFromValue("APICAST_CONFIGURATION_LOADER", *a.ConfigurationLoadMode))
 	}
@@ -139,14 +503,23 @@ func (a *APIcast) deploymentEnv() []v1.EnvVar {
 		env = append(env, a.envVarFromValue("APICAST_LOG_LEVEL", *a.LogLevel))
 	}
 
+	if a.OIDCLogLevel != nil {
+		env = append(env, a.envVarFromValue("APICAST_OIDC_LOG_LEVEL", *a.OIDCLogLevel))
+	}
+
 	if a.PathRoutingEnabled != nil {
 		env = append(env, a.envVarFromValue("APICAST_PATH_ROUTING", strconv.FormatBool(*a.PathRoutingEnabled)))
 	}
 
+	if a.PathRoutingOnly != nil {
+		env = append(env, a.envVarFromValue("APICAST_PATH_ROUTING_ONLY", strconv.FormatBool(*a.PathRoutingOnly)))
+	}
+
 	if a.ResponseCodesIncluded != nil {
 		env = append(env, a.envVarFromValue("APICAST_RESPONSE_CODES", strconv.FormatBool(*a.ResponseCodesIncluded)))
 	}
 
+	// 0 is a meaningful value, it disables the cache
 	if a.CacheConfigurationSeconds != nil {
 		env = append(env, a.envVarFromValue("APICAST_CONFIGURATION_CACHE", strconv.FormatInt(*a.CacheConfigurationSeconds, 10)))
 	}
@@ -155,10 +528,79 @@ func (a *APIcast) deploymentEnv() []v1.EnvVar {
 		env = append(env, a.envVarFromValue("APICAST_MANAGEMENT_API", *a.ManagementAPIScope))
 	}
 
+	if a.BackendCacheHandler != nil {
+		env = append(env, a.envVarFromValue("APICAST_BACKEND_CACHE_HANDLER", *a.BackendCacheHandler))
+	}
+
 	if a.OpenSSLPeerVerificationEnabled != nil {
 		env = append(env, a.envVarFromValue("OPENSSL_VERIFY", strconv.FormatBool(*a.OpenSSLPeerVerificationEnabled)))
 	}
 
+	if len(a.PolicyLoadPath) > 0 {
+		env = append(env, a.envVarFromValue("APICAST_POLICY_LOAD_PATH", strings.Join(a.PolicyLoadPath, ":")))
+	} else if len(a.CustomPolicies) > 0 {
+		env = append(env, a.envVarFromValue("APICAST_POLICY_LOAD_PATH", CustomPoliciesMountPath))
+	}
+
+	if a.LazyLoadServices != nil {
+		env = append(env, a.envVarFromValue("APICAST_LOAD_SERVICES_WHEN_NEEDED", strconv.FormatBool(*a.LazyLoadServices)))
+	}
+
+	if a.ExtendedMetrics != nil {
+		env = append(env, a.envVarFromValue("APICAST_EXTENDED_METRICS", strconv.FormatBool(*a.ExtendedMetrics)))
+	}
+
+	if a.UpstreamKeepaliveRequests != nil {
+		env = append(env, a.envVarFromValue("APICAST_LUA_SOCKET_KEEPALIVE_REQUESTS", strconv.Itoa(int(*a.UpstreamKeepaliveRequests))))
+	}
+
+	if len(a.UpstreamRetryCases) > 0 {
+		env = append(env, a.envVarFromValue("APICAST_UPSTREAM_RETRY_CASES", strings.Join(a.UpstreamRetryCases, " ")))
+	}
+
+	if a.UpstreamTLSSecretName != nil {
+		env = append(env,
+			a.envVarFromValue("APICAST_PROXY_HTTPS_CERTIFICATE", path.Join(UpstreamTLSMountPath, v1.TLSCertKey)),
+			a.envVarFromValue("APICAST_PROXY_HTTPS_CERTIFICATE_KEY", path.Join(UpstreamTLSMountPath, v1.TLSPrivateKeyKey)),
+		)
+	}
+
+	if a.ReportingThreads != nil {
+		env = append(env, a.envVarFromValue("APICAST_REPORTING_THREADS", strconv.Itoa(int(*a.ReportingThreads))))
+	}
+
+	if a.BatcherSharedMemorySizeMiB != nil {
+		env = append(env, a.envVarFromValue("APICAST_POLICY_BATCHER_SHARED_MEMORY_SIZE", fmt.Sprintf("%dm", *a.BatcherSharedMemorySizeMiB)))
+	}
+
+	if a.HTTPProxy != nil {
+		env = append(env, a.envVarFromValue("HTTP_PROXY", *a.HTTPProxy))
+	}
+
+	if a.HTTPSProxy != nil {
+		env = append(env, a.envVarFromValue("HTTPS_PROXY", *a.HTTPSProxy))
+	}
+
+	if a.NoProxy != nil {
+		env = append(env, a.envVarFromValue("NO_PROXY", *a.NoProxy))
+	}
+
+	if a.HTTPSPort != nil {
+		env = append(env, a.envVarFromValue("APICAST_HTTPS_PORT", strconv.FormatInt(int64(*a.HTTPSPort), 10)))
+	}
+
+	if a.HTTPSVerifyDepth != nil {
+		env = append(env, a.envVarFromValue("APICAST_HTTPS_VERIFY_DEPTH", strconv.FormatInt(*a.HTTPSVerifyDepth, 10)))
+	}
+
+	if a.AccessLogFile != nil {
+		env = append(env, a.envVarFromValue("APICAST_ACCESS_LOG_FILE", *a.AccessLogFile))
+	}
+
+	if a.ErrorLogFile != nil {
+		env = append(env, a.envVarFromValue("APICAST_LOG_FILE", *a.ErrorLogFile))
+	}
+
 	if a.GatewayConfigurationSecretName != nil {
 		env = append(env, v1.EnvVar{
 			Name:  "THREESCALE_CONFIG_FILE",
@@ -166,6 +608,27 @@ func (a *APIcast) deploymentEnv() []v1.EnvVar {
 		})
 	}
 
+	if a.GatewayConfigurationClaimName != nil {
+		env = append(env, v1.EnvVar{
+			Name:  "THREESCALE_CONFIG_FILE",
+			Value: path.Join(EmbeddedConfigurationMountPath, a.GatewayConfigurationClaimPath),
+		})
+	}
+
+	if a.RemoteConfigurationURL != nil {
+		configurationURL := *a.RemoteConfigurationURL
+		if a.RemoteConfigurationSecretName != nil {
+			// The credentials are expanded by Kubernetes from the env vars
+			// defined before, so they are not visible in the workload
+			env = append(env,
+				a.envVarFromSecretKey("APICAST_CONFIGURATION_USERNAME", *a.RemoteConfigurationSecretName, v1.BasicAuthUsernameKey),
+				a.envVarFromSecretKey("APICAST_CONFIGURATION_PASSWORD", *a.RemoteConfigurationSecretName, v1.BasicAuthPasswordKey),
+			)
+			configurationURL = strings.Replace(configurationURL, "://", "://$(APICAST_CONFIGURATION_USERNAME):$(APICAST_CONFIGURATION_PASSWORD)@", 1)
+		}
+		env = append(env, a.envVarFromValue("APICAST_CONFIGURATION", configurationURL))
+	}
+
 	return env
 }
 
@@ -177,9 +640,10 @@ func (a *APIcast) Deployment() *appsv1.Deployment {
 			Kind:       "Deployment",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      a.DeploymentName,
-			Namespace: a.Namespace,
-			Labels:    a.commonLabels(),
+			Name:        a.DeploymentName,
+			Namespace:   a.Namespace,
+			Labels:      a.commonLabels(),
+			Annotations: a.workloadAnnotations(),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Selector: &metav1.LabelSelector{
@@ -188,52 +652,293 @@ func (a *APIcast) Deployment() *appsv1.Deployment {
 			Strategy: appsv1.DeploymentStrategy{
 				Type: appsv1.RollingUpdateDeploymentStrategyType,
 			},
-			Template: v1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels:      a.deploymentLabelSelector(),
-					Annotations: a.podAnnotations(),
-				},
-				Spec: v1.PodSpec{
-					ServiceAccountName: a.ServiceAccountName,
-					Volumes:            a.deploymentVolumes(),
-					Containers: []v1.Container{
-						v1.Container{
-							Name: a.DeploymentName,
-							Ports: []v1.ContainerPort{
-								v1.ContainerPort{Name: "proxy", ContainerPort: 8080, Protocol: v1.ProtocolTCP},
-								v1.ContainerPort{Name: "management", ContainerPort: 8090, Protocol: v1.ProtocolTCP},
-								v1.ContainerPort{Name: "metrics", ContainerPort: 9421, Protocol: v1.ProtocolTCP},
-							},
-							Image:           a.Image,
-							ImagePullPolicy: v1.PullAlways, // This is different than the currently used which is IfNotPresent
-							Resources: v1.ResourceRequirements{
-								Limits: v1.ResourceList{
-									v1.ResourceCPU:    resource.MustParse("1"),
-									v1.ResourceMemory: resource.MustParse("128Mi"),
-								},
-								Requests: v1.ResourceList{
-									v1.ResourceCPU:    resource.MustParse("500m"),
-									v1.ResourceMemory: resource.MustParse("64Mi"),
-								},
-							},
-							LivenessProbe:  a.livenessProbe(),
-							ReadinessProbe: a.readinessProbe(),
-							VolumeMounts:   a.deploymentVolumeMounts(),
-							// Env takes precedence with respect to EnvFrom on duplicated
-							// var values
-							Env: a.deploymentEnv(),
+			Template:                a.podTemplateSpec(),
+			Replicas:                &a.Replicas, // TODO set to nil?
+			RevisionHistoryLimit:    a.RevisionHistoryLimit,
+			MinReadySeconds:         a.MinReadySeconds,
+			ProgressDeadlineSeconds: a.ProgressDeadlineSeconds,
+		},
+	}
+
+	if a.OwnerReference != nil {
+		addOwnerRefToObject(deployment, *a.OwnerReference)
+	}
+
+	return deployment
+}
+
+// workloadAnnotations returns the annotations of the Deployment or the
+// DaemonSet running the gateway pods
+func (a *APIcast) workloadAnnotations() map[string]string {
+	annotations := map[string]string{}
+	for key, val := range a.DeploymentAnnotations {
+		annotations[key] = val
+	}
+	return annotations
+}
+
+// DaemonSet returns the workload running one gateway pod per node. It has
+// the same name and pod template as the Deployment
+func (a *APIcast) DaemonSet() *appsv1.DaemonSet {
+	daemonSet := &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "DaemonSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        a.DeploymentName,
+			Namespace:   a.Namespace,
+			Labels:      a.commonLabels(),
+			Annotations: a.workloadAnnotations(),
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: a.deploymentLabelSelector(),
+			},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+			},
+			Template:             a.podTemplateSpec(),
+			RevisionHistoryLimit: a.RevisionHistoryLimit,
+			MinReadySeconds:      a.MinReadySeconds,
+		},
+	}
+
+	if a.OwnerReference != nil {
+		addOwnerRefToObject(daemonSet, *a.OwnerReference)
+	}
+
+	return daemonSet
+}
+
+// podTemplateSpec returns the template of the gateway pods. The gateway
+// container is the first one, followed by the sidecars
+func (a *APIcast) podTemplateSpec() v1.PodTemplateSpec {
+	template := v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      a.podLabels(),
+			Annotations: a.podAnnotations(),
+		},
+		Spec: v1.PodSpec{
+			ServiceAccountName:            a.ServiceAccountName,
+			AutomountServiceAccountToken:  a.AutomountServiceAccountToken,
+			TerminationGracePeriodSeconds: a.TerminationGracePeriodSeconds,
+			PriorityClassName:             a.priorityClassName(),
+			DNSPolicy:                     a.dnsPolicy(),
+			DNSConfig:                     a.DNSConfig,
+			Volumes:                       a.deploymentVolumes(),
+			InitContainers:                a.initContainers(),
+			Containers: []v1.Container{
+				v1.Container{
+					Name:            a.DeploymentName,
+					Ports:           a.containerPorts(),
+					Image:           a.Image,
+					Command:         a.Command,
+					Args:            a.Args,
+					ImagePullPolicy: v1.PullAlways, // This is different than the currently used which is IfNotPresent
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("1"),
+							v1.ResourceMemory: resource.MustParse("128Mi"),
+						},
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("500m"),
+							v1.ResourceMemory: resource.MustParse("64Mi"),
 						},
 					},
+					LivenessProbe:  a.livenessProbe(),
+					ReadinessProbe: a.readinessProbe(),
+					Lifecycle:      a.lifecycle(),
+					VolumeMounts:   a.deploymentVolumeMounts(),
+					// Env takes precedence with respect to EnvFrom on duplicated
+					// var values
+					Env: a.deploymentEnv(),
 				},
 			},
-			Replicas: &a.Replicas, // TODO set to nil?
 		},
 	}
-	if a.OwnerReference != nil {
-		addOwnerRefToObject(deployment, *a.OwnerReference)
+
+	if a.LogForwarder != nil {
+		template.Spec.Containers = append(template.Spec.Containers, a.logForwarderContainer())
 	}
 
-	return deployment
+	if a.SecureMetrics != nil {
+		template.Spec.Containers = append(template.Spec.Containers, a.secureMetricsContainer())
+	}
+
+	if a.HotReloadImage != nil {
+		shareProcessNamespace := true
+		template.Spec.ShareProcessNamespace = &shareProcessNamespace
+		template.Spec.Containers = append(template.Spec.Containers, a.hotReloadContainer())
+	}
+
+	return template
+}
+
+// hotReloadContainer returns the sidecar container that reloads the gateway
+// when the embedded configuration changes. The pod process namespace is
+// shared so it can send SIGHUP to the nginx master process
+func (a *APIcast) hotReloadContainer() v1.Container {
+	return v1.Container{
+		Name:  HotReloadContainerName,
+		Image: *a.HotReloadImage,
+		VolumeMounts: []v1.VolumeMount{
+			v1.VolumeMount{
+				Name:      EmbeddedConfigurationVolumeName,
+				MountPath: EmbeddedConfigurationMountPath,
+				ReadOnly:  true,
+			},
+		},
+		Env: []v1.EnvVar{
+			a.envVarFromValue("WATCH_PATH", EmbeddedConfigurationMountPath),
+		},
+	}
+}
+
+// secureMetricsContainer returns the sidecar container serving the metrics
+// of the gateway over TLS and/or with basic authentication. It proxies the
+// requests to the metrics endpoint of the gateway in localhost
+func (a *APIcast) secureMetricsContainer() v1.Container {
+	container := v1.Container{
+		Name:  SecureMetricsContainerName,
+		Image: a.SecureMetrics.Image,
+		Ports: []v1.ContainerPort{
+			v1.ContainerPort{Name: "secure-metrics", ContainerPort: SecureMetricsContainerPort, Protocol: v1.ProtocolTCP},
+		},
+		Env: []v1.EnvVar{
+			a.envVarFromValue("SECURE_METRICS_PORT", strconv.Itoa(int(SecureMetricsContainerPort))),
+			a.envVarFromValue("METRICS_UPSTREAM", fmt.Sprintf("http://127.0.0.1:%d/metrics", MetricsContainerPort)),
+		},
+	}
+
+	if a.SecureMetrics.TLSSecretName != nil {
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+			Name:      SecureMetricsTLSVolumeName,
+			MountPath: SecureMetricsTLSMountPath,
+			ReadOnly:  true,
+		})
+		container.Env = append(container.Env,
+			a.envVarFromValue("TLS_CERT_FILE", path.Join(SecureMetricsTLSMountPath, v1.TLSCertKey)),
+			a.envVarFromValue("TLS_KEY_FILE", path.Join(SecureMetricsTLSMountPath, v1.TLSPrivateKeyKey)),
+		)
+	}
+
+	if a.SecureMetrics.BasicAuthSecretName != nil {
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+			Name:      SecureMetricsBasicAuthVolumeName,
+			MountPath: SecureMetricsBasicAuthMountPath,
+			ReadOnly:  true,
+		})
+		container.Env = append(container.Env,
+			a.envVarFromValue("BASIC_AUTH_USERNAME_FILE", path.Join(SecureMetricsBasicAuthMountPath, v1.BasicAuthUsernameKey)),
+			a.envVarFromValue("BASIC_AUTH_PASSWORD_FILE", path.Join(SecureMetricsBasicAuthMountPath, v1.BasicAuthPasswordKey)),
+		)
+	}
+
+	return container
+}
+
+// metricsContainerPort returns the pod port serving the metrics: the one of
+// the secure metrics sidecar, when enabled, or the gateway one
+func (a *APIcast) metricsContainerPort() int32 {
+	if a.SecureMetrics != nil {
+		return SecureMetricsContainerPort
+	}
+	return MetricsContainerPort
+}
+
+// initContainers returns the init containers of the pod. They share the env
+// vars and volume mounts of the gateway container so they can check its
+// configuration
+func (a *APIcast) initContainers() []v1.Container {
+	var containers []v1.Container
+	for _, initContainer := range a.InitContainers {
+		containers = append(containers, v1.Container{
+			Name:         initContainer.Name,
+			Image:        initContainer.Image,
+			Command:      initContainer.Command,
+			Args:         initContainer.Args,
+			VolumeMounts: a.deploymentVolumeMounts(),
+			Env:          a.deploymentEnv(),
+		})
+	}
+	if a.CacheConfigurationJitterSeconds != nil && *a.CacheConfigurationJitterSeconds > 0 {
+		containers = append(containers, a.configurationJitterContainer())
+	}
+	return containers
+}
+
+// ConfigurationJitterContainerName is the name of the init container added
+// to delay the start of the gateway
+const ConfigurationJitterContainerName = "configuration-jitter"
+
+// InitContainerNames are the names of the init containers the operator can
+// add to the APIcast pods. They cannot be used by the user provided init
+// containers, whether the operator adds them or not
+var InitContainerNames = []string{
+	ConfigurationJitterContainerName,
+}
+
+// IsReservedInitContainerName returns whether the init container name is
+// used by the init containers added by the operator
+func IsReservedInitContainerName(name string) bool {
+	for _, reservedName := range InitContainerNames {
+		if name == reservedName {
+			return true
+		}
+	}
+	return false
+}
+
+// configurationJitterContainer returns the init container that delays the
+// start of the gateway a random number of seconds, up to
+// CacheConfigurationJitterSeconds, so the periodic reloads of the
+// configuration, scheduled from the start of each pod, are spread over time.
+// The delay is paid by every new pod, i.e. in rollouts and scale ups, as
+// APIcast cannot delay only its first reload. It runs the gateway image,
+// which provides bash
+func (a *APIcast) configurationJitterContainer() v1.Container {
+	return v1.Container{
+		Name:    ConfigurationJitterContainerName,
+		Image:   a.Image,
+		Command: []string{"/bin/bash", "-c", fmt.Sprintf("sleep $((RANDOM %% %d))", *a.CacheConfigurationJitterSeconds+1)},
+	}
+}
+
+// logsVolumeEnabled returns whether the shared logs volume is needed by the
+// access log file or the error log file
+func (a *APIcast) logsVolumeEnabled() bool {
+	return a.AccessLogFile != nil || a.ErrorLogFile != nil
+}
+
+// logForwarderContainer returns the sidecar container that ships the access
+// logs. It reads them from the shared logs volume, and the path of the
+// access log file is provided in the APICAST_ACCESS_LOG_FILE env var. The
+// path of the error log file, if any, is provided in the APICAST_LOG_FILE
+// env var
+func (a *APIcast) logForwarderContainer() v1.Container {
+	container := v1.Container{
+		Name:    LogForwarderContainerName,
+		Image:   a.LogForwarder.Image,
+		Command: a.LogForwarder.Command,
+		Args:    a.LogForwarder.Args,
+		VolumeMounts: []v1.VolumeMount{
+			v1.VolumeMount{
+				Name:      AccessLogsVolumeName,
+				MountPath: AccessLogsMountPath,
+				ReadOnly:  true,
+			},
+		},
+		Env: []v1.EnvVar{
+			a.envVarFromValue("APICAST_ACCESS_LOG_FILE", *a.AccessLogFile),
+		},
+	}
+
+	if a.ErrorLogFile != nil {
+		container.Env = append(container.Env, a.envVarFromValue("APICAST_LOG_FILE", *a.ErrorLogFile))
+	}
+
+	return container
 }
 
 func (a *APIcast) deploymentLabelSelector() map[string]string {
@@ -242,6 +947,31 @@ func (a *APIcast) deploymentLabelSelector() map[string]string {
 	}
 }
 
+// PodSelector returns the label selector of the gateway pods, which depends
+// on the name of the generated resources
+func (a *APIcast) PodSelector() string {
+	return labels.SelectorFromSet(a.deploymentLabelSelector()).String()
+}
+
+// serviceAnnotations returns the annotations of the Service of the gateway
+func (a *APIcast) serviceAnnotations() map[string]string {
+	annotations := map[string]string{}
+	for key, val := range a.ServiceAnnotations {
+		annotations[key] = val
+	}
+	return annotations
+}
+
+// serviceSelector returns the selector of the Service of the gateway. The
+// selector of the standby gateways does not match their pods
+func (a *APIcast) serviceSelector() map[string]string {
+	selector := a.deploymentLabelSelector()
+	if a.Standby {
+		selector[StandbyLabel] = "true"
+	}
+	return selector
+}
+
 func (a *APIcast) commonLabels() map[string]string {
 	return map[string]string{
 		"app":                  a.AppLabel,
@@ -249,10 +979,31 @@ func (a *APIcast) commonLabels() map[string]string {
 	}
 }
 
+// podLabels returns the labels of the gateway pods. The selector labels take
+// precedence, as the selector of the workload is immutable
+func (a *APIcast) podLabels() map[string]string {
+	labels := map[string]string{}
+	for key, val := range a.PodLabels {
+		if key != StandbyLabel {
+			labels[key] = val
+		}
+	}
+
+	for key, val := range a.deploymentLabelSelector() {
+		labels[key] = val
+	}
+
+	return labels
+}
+
 func (a *APIcast) podAnnotations() map[string]string {
 	annotations := map[string]string{
 		"prometheus.io/scrape": "true",
-		"prometheus.io/port":   "9421",
+		"prometheus.io/port":   strconv.Itoa(int(a.metricsContainerPort())),
+	}
+
+	if a.SecureMetrics != nil && a.SecureMetrics.TLSSecretName != nil {
+		annotations["prometheus.io/scheme"] = "https"
 	}
 
 	for key, val := range a.AdditionalAnnotations {
@@ -269,15 +1020,87 @@ func (a *APIcast) Service() *v1.Service {
 			Kind:       "Service",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      a.ServiceName,
+			Name:        a.ServiceName,
+			Namespace:   a.Namespace,
+			Labels:      a.commonLabels(),
+			Annotations: a.serviceAnnotations(),
+		},
+		Spec: v1.ServiceSpec{
+			Type:            v1.ServiceTypeClusterIP,
+			Ports:           a.servicePorts(),
+			Selector:        a.serviceSelector(),
+			SessionAffinity: v1.ServiceAffinityNone,
+		},
+	}
+
+	// The defaults of the API server are set explicitly, so the fields are
+	// restored when the settings are removed
+	if a.ServiceType != nil {
+		service.Spec.Type = *a.ServiceType
+	}
+
+	if a.ServiceSessionAffinity != nil {
+		service.Spec.SessionAffinity = *a.ServiceSessionAffinity
+	}
+
+	if service.Spec.Type == v1.ServiceTypeNodePort || service.Spec.Type == v1.ServiceTypeLoadBalancer {
+		service.Spec.ExternalTrafficPolicy = v1.ServiceExternalTrafficPolicyTypeCluster
+		if a.ServiceExternalTrafficPolicy != nil {
+			service.Spec.ExternalTrafficPolicy = *a.ServiceExternalTrafficPolicy
+		}
+	}
+
+	if a.OwnerReference != nil {
+		addOwnerRefToObject(service, *a.OwnerReference)
+	}
+
+	return service
+}
+
+// ServiceAccount returns the ServiceAccount created by the operator for the
+// gateway pods. It is only used when ManagedServiceAccount is set
+func (a *APIcast) ServiceAccount() *v1.ServiceAccount {
+	serviceAccount := &v1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.ServiceAccountName,
+			Namespace: a.Namespace,
+			Labels:    a.commonLabels(),
+		},
+	}
+
+	if a.OwnerReference != nil {
+		addOwnerRefToObject(serviceAccount, *a.OwnerReference)
+	}
+
+	return serviceAccount
+}
+
+// ManagementServiceName returns the name of the Service exposing only the
+// management port
+func (a *APIcast) ManagementServiceName() string {
+	return a.ServiceName + "-management"
+}
+
+// ManagementService returns the internal Service exposing only the
+// management port. It is only used when ManagementServiceEnabled is set
+func (a *APIcast) ManagementService() *v1.Service {
+	service := &v1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.ManagementServiceName(),
 			Namespace: a.Namespace,
 			Labels:    a.commonLabels(),
 		},
 		Spec: v1.ServiceSpec{
-			Ports: []v1.ServicePort{
-				v1.ServicePort{Name: "proxy", Port: 8080, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(8080)},
-				v1.ServicePort{Name: "management", Port: 8090, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(8090)},
-			},
+			Type:     v1.ServiceTypeClusterIP,
+			Ports:    []v1.ServicePort{a.managementServicePort()},
 			Selector: a.deploymentLabelSelector(),
 		},
 	}
@@ -289,72 +1112,382 @@ func (a *APIcast) Service() *v1.Service {
 	return service
 }
 
+func (a *APIcast) managementServicePort() v1.ServicePort {
+	return v1.ServicePort{Name: a.ManagementPort.Name, Port: a.ManagementPort.Port, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(int(ManagementContainerPort))}
+}
+
+func (a *APIcast) containerPorts() []v1.ContainerPort {
+	ports := []v1.ContainerPort{
+		v1.ContainerPort{Name: a.ProxyPort.Name, ContainerPort: ProxyContainerPort, Protocol: v1.ProtocolTCP},
+		v1.ContainerPort{Name: a.ManagementPort.Name, ContainerPort: ManagementContainerPort, Protocol: v1.ProtocolTCP},
+		v1.ContainerPort{Name: a.MetricsPort.Name, ContainerPort: MetricsContainerPort, Protocol: v1.ProtocolTCP},
+	}
+
+	if a.HTTPSPort != nil {
+		ports = append(ports, v1.ContainerPort{Name: "https", ContainerPort: *a.HTTPSPort, Protocol: v1.ProtocolTCP})
+	}
+
+	return ports
+}
+
+func (a *APIcast) servicePorts() []v1.ServicePort {
+	ports := []v1.ServicePort{
+		v1.ServicePort{Name: a.ProxyPort.Name, Port: a.ProxyPort.Port, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(int(ProxyContainerPort))},
+	}
+
+	if !a.ManagementServiceEnabled && !a.ManagementPortHidden {
+		ports = append(ports, a.managementServicePort())
+	}
+
+	if a.MetricsServicePortEnabled {
+		ports = append(ports, v1.ServicePort{Name: a.MetricsPort.Name, Port: a.MetricsPort.Port, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(int(a.metricsContainerPort()))})
+	}
+
+	if a.HTTPSPort != nil {
+		ports = append(ports, v1.ServicePort{Name: "https", Port: *a.HTTPSPort, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(int(*a.HTTPSPort))})
+	}
+
+	return ports
+}
+
+// livenessProbe returns the probe checking that the gateway is running. All
+// the fields defaulted by the API server are set so the probe can be
+// compared with the existing one
 func (a *APIcast) livenessProbe() *v1.Probe {
+	timing := DefaultLivenessProbeTiming
+	if a.LivenessProbeTiming != nil {
+		timing = *a.LivenessProbeTiming
+	}
+
+	port := ManagementContainerPort
+	if a.LivenessProbePort != nil {
+		port = *a.LivenessProbePort
+	}
+
 	return &v1.Probe{
 		Handler: v1.Handler{
 			HTTPGet: &v1.HTTPGetAction{
-				Path: "/status/live",
-				Port: intstr.FromInt(8090),
+				Path:   "/status/live",
+				Port:   intstr.FromInt(int(port)),
+				Scheme: v1.URISchemeHTTP,
 			},
 		},
-		InitialDelaySeconds: 10,
-		TimeoutSeconds:      5,
-		PeriodSeconds:       10,
+		InitialDelaySeconds: timing.InitialDelaySeconds,
+		TimeoutSeconds:      timing.TimeoutSeconds,
+		PeriodSeconds:       timing.PeriodSeconds,
+		SuccessThreshold:    timing.SuccessThreshold,
+		FailureThreshold:    timing.FailureThreshold,
 	}
 }
 
+// readinessProbe returns the probe checking that the gateway has loaded
+// its configuration. All the fields defaulted by the API server are set so
+// the probe can be compared with the existing one
 func (a *APIcast) readinessProbe() *v1.Probe {
+	timing := DefaultReadinessProbeTiming
+	if a.ReadinessProbeTiming != nil {
+		timing = *a.ReadinessProbeTiming
+	}
+
 	return &v1.Probe{
 		Handler: v1.Handler{
 			HTTPGet: &v1.HTTPGetAction{
-				Path: "/status/ready",
-				Port: intstr.FromInt(8090),
+				Path:   "/status/ready",
+				Port:   intstr.FromInt(int(a.readinessProbePort())),
+				Scheme: v1.URISchemeHTTP,
+			},
+		},
+		InitialDelaySeconds: timing.InitialDelaySeconds,
+		TimeoutSeconds:      timing.TimeoutSeconds,
+		PeriodSeconds:       timing.PeriodSeconds,
+		SuccessThreshold:    timing.SuccessThreshold,
+		FailureThreshold:    timing.FailureThreshold,
+	}
+}
+
+// readinessProbePort returns the container port checked by the readiness
+// probe. Defaults to the management port
+func (a *APIcast) readinessProbePort() int32 {
+	if a.ReadinessProbePort == nil {
+		return ManagementContainerPort
+	}
+	return *a.ReadinessProbePort
+}
+
+func (a *APIcast) priorityClassName() string {
+	if a.PriorityClassName == nil {
+		return ""
+	}
+	return *a.PriorityClassName
+}
+
+// dnsPolicy returns the DNS policy of the pods. The default of the API
+// server is set explicitly, so it is restored when the policy is removed
+func (a *APIcast) dnsPolicy() v1.DNSPolicy {
+	if a.DNSPolicy == nil {
+		return v1.DNSClusterFirst
+	}
+	return *a.DNSPolicy
+}
+
+func (a *APIcast) lifecycle() *v1.Lifecycle {
+	if a.PreStopCommand == nil {
+		return nil
+	}
+
+	return &v1.Lifecycle{
+		PreStop: &v1.Handler{
+			Exec: &v1.ExecAction{
+				Command: a.PreStopCommand,
 			},
 		},
-		InitialDelaySeconds: 15,
-		TimeoutSeconds:      5,
-		PeriodSeconds:       30,
 	}
 }
 
 func (a *APIcast) Ingress() *extensions.Ingress {
 	ingress := &extensions.Ingress{
 		TypeMeta: metav1.TypeMeta{
-			APIVersion: "networking.k8s.io/v1beta1",
+			APIVersion: "extensions/v1beta1",
 			Kind:       "Ingress",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      a.DeploymentName,
-			Namespace: a.Namespace,
-			Labels:    a.commonLabels(),
+			Name:        a.DeploymentName,
+			Namespace:   a.Namespace,
+			Labels:      a.commonLabels(),
+			Annotations: a.ingressAnnotations(),
 		},
 		Spec: extensions.IngressSpec{
-			TLS: a.ExposedHost.TLS,
-			Rules: []extensions.IngressRule{
-				{
-					Host: a.ExposedHost.Host,
-					IngressRuleValue: extensions.IngressRuleValue{
-						HTTP: &extensions.HTTPIngressRuleValue{
-							Paths: []extensions.HTTPIngressPath{
-								{
-									Backend: extensions.IngressBackend{
-										ServiceName: a.DeploymentName,
-										ServicePort: intstr.FromString("proxy"),
-									},
-								},
+			TLS:   a.ingressTLS(),
+			Rules: a.ingressRules(),
+		},
+	}
+
+	if a.OwnerReference != nil {
+		addOwnerRefToObject(ingress, *a.OwnerReference)
+	}
+
+	return ingress
+}
+
+// ingressAnnotations returns the annotations of the Ingress of the exposed
+// hosts
+func (a *APIcast) ingressAnnotations() map[string]string {
+	annotations := map[string]string{}
+	for key, val := range a.ExposedHost.Annotations {
+		annotations[key] = val
+	}
+	return annotations
+}
+
+func (a *APIcast) ingressRules() []extensions.IngressRule {
+	var rules []extensions.IngressRule
+	for _, host := range a.ExposedHost.Hosts() {
+		rules = append(rules, extensions.IngressRule{
+			Host: host,
+			IngressRuleValue: extensions.IngressRuleValue{
+				HTTP: &extensions.HTTPIngressRuleValue{
+					Paths: []extensions.HTTPIngressPath{
+						{
+							Path: a.ExposedHost.Path,
+							Backend: extensions.IngressBackend{
+								ServiceName: a.DeploymentName,
+								ServicePort: intstr.FromString(a.ProxyPort.Name),
 							},
 						},
 					},
 				},
 			},
+		})
+	}
+	return rules
+}
+
+// ingressTLS returns the TLS configuration of the Ingress. TLS entries
+// without hosts are set to cover all the exposed hosts
+func (a *APIcast) ingressTLS() []extensions.IngressTLS {
+	var tls []extensions.IngressTLS
+	for _, tlsEntry := range a.ExposedHost.TLS {
+		if len(tlsEntry.Hosts) == 0 {
+			tlsEntry.Hosts = a.ExposedHost.Hosts()
+		}
+		tls = append(tls, tlsEntry)
+	}
+
+	if a.ExposedHost.CertManagerClusterIssuer != nil {
+		tls = append(tls, extensions.IngressTLS{
+			Hosts:      a.ExposedHost.Hosts(),
+			SecretName: a.CertificateSecretName(),
+		})
+	}
+	return tls
+}
+
+// CertificateSecretName returns the name of the secret where cert-manager
+// stores the certificate of the exposed hosts
+func (a *APIcast) CertificateSecretName() string {
+	return a.DeploymentName + "-tls"
+}
+
+// Certificate returns the cert-manager Certificate of the exposed hosts. It
+// is unstructured, as the cert-manager API is optional
+func (a *APIcast) Certificate() *unstructured.Unstructured {
+	dnsNames := []interface{}{}
+	for _, host := range a.ExposedHost.Hosts() {
+		dnsNames = append(dnsNames, host)
+	}
+
+	certificate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"secretName": a.CertificateSecretName(),
+				"dnsNames":   dnsNames,
+				"issuerRef": map[string]interface{}{
+					"name":  *a.ExposedHost.CertManagerClusterIssuer,
+					"kind":  "ClusterIssuer",
+					"group": "cert-manager.io",
+				},
+			},
 		},
 	}
+	certificate.SetAPIVersion(CertificateAPIVersion)
+	certificate.SetKind(CertificateKind)
+	certificate.SetName(a.DeploymentName)
+	certificate.SetNamespace(a.Namespace)
+	certificate.SetLabels(a.commonLabels())
 
 	if a.OwnerReference != nil {
-		addOwnerRefToObject(ingress, *a.OwnerReference)
+		addOwnerRefToObject(certificate, *a.OwnerReference)
 	}
 
-	return ingress
+	return certificate
+}
+
+// NetworkPolicy returns the NetworkPolicy of the gateway pods. The ingress
+// traffic is only allowed to the gateway ports, and the egress traffic is
+// only restricted when egress rules are set, in which case DNS is allowed
+func (a *APIcast) NetworkPolicy() *networkingv1.NetworkPolicy {
+	networkPolicy := &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.DeploymentName,
+			Namespace: a.Namespace,
+			Labels:    a.commonLabels(),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: a.deploymentLabelSelector()},
+			Ingress:     a.networkPolicyIngress(),
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+
+	if len(a.NetworkPolicyRules.Egress) > 0 {
+		networkPolicy.Spec.Egress = a.networkPolicyEgress()
+		networkPolicy.Spec.PolicyTypes = append(networkPolicy.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+	}
+
+	if a.OwnerReference != nil {
+		addOwnerRefToObject(networkPolicy, *a.OwnerReference)
+	}
+
+	return networkPolicy
+}
+
+func (a *APIcast) networkPolicyIngress() []networkingv1.NetworkPolicyIngressRule {
+	proxyPorts := []networkingv1.NetworkPolicyPort{networkPolicyPort(v1.ProtocolTCP, ProxyContainerPort)}
+	if a.HTTPSPort != nil {
+		proxyPorts = append(proxyPorts, networkPolicyPort(v1.ProtocolTCP, *a.HTTPSPort))
+	}
+
+	// The pods of the namespace are allowed by default, i.e. to scrape
+	// the metrics
+	managementFrom := a.NetworkPolicyRules.ManagementFrom
+	if len(managementFrom) == 0 {
+		managementFrom = []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}
+	}
+
+	return []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: proxyPorts,
+			From:  a.NetworkPolicyRules.ProxyFrom,
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				networkPolicyPort(v1.ProtocolTCP, ManagementContainerPort),
+				networkPolicyPort(v1.ProtocolTCP, a.metricsContainerPort()),
+			},
+			From: managementFrom,
+		},
+	}
+}
+
+// networkPolicyEgress returns the egress rules set in the APIcast resource
+// and the DNS one. The protocols defaulted by the API server are set so the
+// rules can be compared with the existing ones
+func (a *APIcast) networkPolicyEgress() []networkingv1.NetworkPolicyEgressRule {
+	egress := []networkingv1.NetworkPolicyEgressRule{}
+	for _, rule := range a.NetworkPolicyRules.Egress {
+		rule = *rule.DeepCopy()
+		for idx := range rule.Ports {
+			if rule.Ports[idx].Protocol == nil {
+				protocol := v1.ProtocolTCP
+				rule.Ports[idx].Protocol = &protocol
+			}
+		}
+		egress = append(egress, rule)
+	}
+
+	return append(egress, networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			networkPolicyPort(v1.ProtocolUDP, 53),
+			networkPolicyPort(v1.ProtocolTCP, 53),
+		},
+	})
+}
+
+func networkPolicyPort(protocol v1.Protocol, port int32) networkingv1.NetworkPolicyPort {
+	portValue := intstr.FromInt(int(port))
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &portValue}
+}
+
+// Render returns the objects generated for the APIcast gateway: the
+// Deployment or the DaemonSet, the Service when it is managed and, when a
+// host is exposed and the Ingress is managed, the Ingress and the
+// cert-manager Certificate when requested
+func (a *APIcast) Render() []runtime.Object {
+	objects := []runtime.Object{}
+	if a.DaemonSetWorkload {
+		objects = append(objects, a.DaemonSet())
+	} else {
+		objects = append(objects, a.Deployment())
+	}
+
+	if a.ManagedService {
+		objects = append(objects, a.Service())
+	}
+
+	if a.ManagementServiceEnabled {
+		objects = append(objects, a.ManagementService())
+	}
+
+	if a.ManagedServiceAccount {
+		objects = append(objects, a.ServiceAccount())
+	}
+
+	if a.NetworkPolicyRules != nil {
+		objects = append(objects, a.NetworkPolicy())
+	}
+
+	if a.ManagedIngress && a.ExposedHost.Host != "" {
+		objects = append(objects, a.Ingress())
+		if a.ExposedHost.CertManagerClusterIssuer != nil {
+			objects = append(objects, a.Certificate())
+		}
+	}
+
+	return objects
 }
 
 func addOwnerRefToObject(o metav1.Object, r metav1.OwnerReference) {
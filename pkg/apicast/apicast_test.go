@@ -0,0 +1,254 @@
+package apicast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func newTestAPIcast() *APIcast {
+	return &APIcast{
+		Namespace:      "operator-unittest",
+		DeploymentName: "apicast-example-apicast",
+		ServiceName:    "apicast-example-apicast",
+		Image:          "quay.io/3scale/apicast:latest",
+		ProxyPort:      Port{Name: DefaultProxyPortName, Port: ProxyContainerPort},
+		ManagementPort: Port{Name: DefaultManagementPortName, Port: ManagementContainerPort},
+		MetricsPort:    Port{Name: DefaultMetricsPortName, Port: MetricsContainerPort},
+	}
+}
+
+// envVars returns the values of the env vars, by name
+func envVars(env []v1.EnvVar) map[string]string {
+	values := map[string]string{}
+	for _, envVar := range env {
+		values[envVar.Name] = envVar.Value
+	}
+	return values
+}
+
+func TestProxyEnv(t *testing.T) {
+	httpProxy := "http://proxy.example.com:3128"
+	httpsProxy := "https://proxy.example.com:3129"
+	noProxy := "localhost,.svc,10.0.0.0/8"
+	cases := []struct {
+		name        string
+		mutate      func(*APIcast)
+		expectedEnv map[string]string
+	}{
+		{"disabled", func(a *APIcast) {}, map[string]string{}},
+		{"HTTP proxy", func(a *APIcast) {
+			a.HTTPProxy = &httpProxy
+		}, map[string]string{"HTTP_PROXY": httpProxy}},
+		{"all", func(a *APIcast) {
+			a.HTTPProxy = &httpProxy
+			a.HTTPSProxy = &httpsProxy
+			a.NoProxy = &noProxy
+		}, map[string]string{"HTTP_PROXY": httpProxy, "HTTPS_PROXY": httpsProxy, "NO_PROXY": noProxy}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newTestAPIcast()
+			tc.mutate(a)
+
+			proxyEnv := map[string]string{}
+			for name, value := range envVars(a.deploymentEnv()) {
+				if name == "HTTP_PROXY" || name == "HTTPS_PROXY" || name == "NO_PROXY" {
+					proxyEnv[name] = value
+				}
+			}
+			assert.Equal(t, tc.expectedEnv, proxyEnv)
+		})
+	}
+}
+
+func TestHTTPSEnv(t *testing.T) {
+	httpsPort := int32(8443)
+	httpsVerifyDepth := int64(3)
+	cases := []struct {
+		name             string
+		httpsPort        *int32
+		httpsVerifyDepth *int64
+		expectedEnv      map[string]string
+	}{
+		{"disabled", nil, nil, map[string]string{}},
+		{"port", &httpsPort, nil, map[string]string{"APICAST_HTTPS_PORT": "8443"}},
+		{"port and verify depth", &httpsPort, &httpsVerifyDepth, map[string]string{"APICAST_HTTPS_PORT": "8443", "APICAST_HTTPS_VERIFY_DEPTH": "3"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newTestAPIcast()
+			a.HTTPSPort = tc.httpsPort
+			a.HTTPSVerifyDepth = tc.httpsVerifyDepth
+
+			httpsEnv := map[string]string{}
+			for name, value := range envVars(a.deploymentEnv()) {
+				if strings.HasPrefix(name, "APICAST_HTTPS_") {
+					httpsEnv[name] = value
+				}
+			}
+			assert.Equal(t, tc.expectedEnv, httpsEnv)
+
+			// The HTTPS port is published by the pod and the Service with
+			// the same number
+			if tc.httpsPort != nil {
+				assert.Contains(t, a.containerPorts(), v1.ContainerPort{Name: "https", ContainerPort: *tc.httpsPort, Protocol: v1.ProtocolTCP})
+				assert.Contains(t, a.servicePorts(), v1.ServicePort{Name: "https", Port: *tc.httpsPort, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(int(*tc.httpsPort))})
+			} else {
+				assert.Len(t, a.containerPorts(), 3)
+			}
+		})
+	}
+}
+
+func TestServicePorts(t *testing.T) {
+	cases := []struct {
+		name          string
+		mutate        func(*APIcast)
+		expectedPorts []v1.ServicePort
+	}{
+		{"default", func(a *APIcast) {}, []v1.ServicePort{
+			{Name: "proxy", Port: 8080, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(8080)},
+			{Name: "management", Port: 8090, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(8090)},
+		}},
+		{"custom names and numbers", func(a *APIcast) {
+			a.ProxyPort = Port{Name: "http", Port: 80}
+			a.ManagementPort = Port{Name: "admin", Port: 9090}
+			a.MetricsPort = Port{Name: "prometheus", Port: 9000}
+			a.MetricsServicePortEnabled = true
+		}, []v1.ServicePort{
+			{Name: "http", Port: 80, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(8080)},
+			{Name: "admin", Port: 9090, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(8090)},
+			{Name: "prometheus", Port: 9000, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(9421)},
+		}},
+		{"secure metrics sidecar", func(a *APIcast) {
+			a.MetricsServicePortEnabled = true
+			a.SecureMetrics = &SecureMetrics{Image: "quay.io/example/metrics-proxy:latest"}
+		}, []v1.ServicePort{
+			{Name: "proxy", Port: 8080, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(8080)},
+			{Name: "management", Port: 8090, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(8090)},
+			{Name: "metrics", Port: 9421, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(int(SecureMetricsContainerPort))},
+		}},
+		{"management Service", func(a *APIcast) {
+			a.ManagementServiceEnabled = true
+		}, []v1.ServicePort{
+			{Name: "proxy", Port: 8080, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromInt(8080)},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newTestAPIcast()
+			tc.mutate(a)
+
+			assert.Equal(t, tc.expectedPorts, a.Service().Spec.Ports)
+
+			// The port numbers of the gateway container do not change, only
+			// their names
+			containerPorts := a.Deployment().Spec.Template.Spec.Containers[0].Ports
+			assert.Equal(t, []v1.ContainerPort{
+				{Name: a.ProxyPort.Name, ContainerPort: ProxyContainerPort, Protocol: v1.ProtocolTCP},
+				{Name: a.ManagementPort.Name, ContainerPort: ManagementContainerPort, Protocol: v1.ProtocolTCP},
+				{Name: a.MetricsPort.Name, ContainerPort: MetricsContainerPort, Protocol: v1.ProtocolTCP},
+			}, containerPorts)
+		})
+	}
+}
+
+func TestPodContainers(t *testing.T) {
+	jitterSeconds := int64(30)
+	noJitterSeconds := int64(0)
+	accessLogFile := DefaultAccessLogFile
+	hotReloadImage := "quay.io/example/hot-reload:latest"
+	cases := []struct {
+		name                   string
+		mutate                 func(*APIcast)
+		expectedInitContainers []string
+		expectedContainers     []string
+	}{
+		{"default", func(a *APIcast) {}, nil, []string{"apicast-example-apicast"}},
+		{"configuration jitter", func(a *APIcast) {
+			a.CacheConfigurationJitterSeconds = &jitterSeconds
+		}, []string{ConfigurationJitterContainerName}, []string{"apicast-example-apicast"}},
+		{"configuration jitter disabled", func(a *APIcast) {
+			a.CacheConfigurationJitterSeconds = &noJitterSeconds
+		}, nil, []string{"apicast-example-apicast"}},
+		{"configuration jitter after the user init containers", func(a *APIcast) {
+			a.CacheConfigurationJitterSeconds = &jitterSeconds
+			a.InitContainers = []InitContainer{{Name: "check-config", Image: "quay.io/example/check-config:latest"}}
+		}, []string{"check-config", ConfigurationJitterContainerName}, []string{"apicast-example-apicast"}},
+		{"sidecars", func(a *APIcast) {
+			a.AccessLogFile = &accessLogFile
+			a.LogForwarder = &LogForwarder{Image: "quay.io/example/log-forwarder:latest"}
+			a.SecureMetrics = &SecureMetrics{Image: "quay.io/example/metrics-proxy:latest"}
+			a.HotReloadImage = &hotReloadImage
+		}, nil, []string{"apicast-example-apicast", LogForwarderContainerName, SecureMetricsContainerName, HotReloadContainerName}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newTestAPIcast()
+			tc.mutate(a)
+			podSpec := a.podTemplateSpec().Spec
+
+			var initContainers []string
+			for _, container := range podSpec.InitContainers {
+				initContainers = append(initContainers, container.Name)
+			}
+			var containers []string
+			for _, container := range podSpec.Containers {
+				containers = append(containers, container.Name)
+			}
+			assert.Equal(t, tc.expectedInitContainers, initContainers)
+			assert.Equal(t, tc.expectedContainers, containers)
+		})
+	}
+}
+
+func TestConfigurationJitterContainer(t *testing.T) {
+	jitterSeconds := int64(30)
+	a := newTestAPIcast()
+	a.CacheConfigurationJitterSeconds = &jitterSeconds
+
+	container := a.configurationJitterContainer()
+	// It runs the gateway image, and the delay includes the jitter seconds
+	assert.Equal(t, a.Image, container.Image)
+	assert.Equal(t, []string{"/bin/bash", "-c", "sleep $((RANDOM % 31))"}, container.Command)
+	assert.True(t, IsReservedInitContainerName(container.Name))
+}
+
+func TestRender(t *testing.T) {
+	clusterIssuer := "letsencrypt"
+	cases := []struct {
+		name          string
+		mutate        func(*APIcast)
+		expectedKinds []string
+	}{
+		{"default", func(a *APIcast) {}, []string{"Deployment", "Service"}},
+		{"exposed host", func(a *APIcast) {
+			a.ExposedHost = ExposedHost{Host: "api.example.com", CertManagerClusterIssuer: &clusterIssuer}
+		}, []string{"Deployment", "Service", "Ingress", CertificateKind}},
+		{"unmanaged Service", func(a *APIcast) {
+			a.ManagedService = false
+		}, []string{"Deployment"}},
+		{"unmanaged Ingress", func(a *APIcast) {
+			a.ExposedHost = ExposedHost{Host: "api.example.com", CertManagerClusterIssuer: &clusterIssuer}
+			a.ManagedIngress = false
+		}, []string{"Deployment", "Service"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newTestAPIcast()
+			a.ManagedService = true
+			a.ManagedIngress = true
+			tc.mutate(a)
+
+			kinds := []string{}
+			for _, object := range a.Render() {
+				kinds = append(kinds, object.GetObjectKind().GroupVersionKind().Kind)
+			}
+			assert.Equal(t, tc.expectedKinds, kinds)
+		})
+	}
+}
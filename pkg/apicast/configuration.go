@@ -0,0 +1,69 @@
+package apicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ValidateEmbeddedConfiguration checks the APIcast configuration is a JSON
+// object, so malformed configurations are detected before the gateway fails
+// to load them
+func ValidateEmbeddedConfiguration(configuration []byte) error {
+	var parsed map[string]interface{}
+	err := json.Unmarshal(configuration, &parsed)
+	if err != nil {
+		return err
+	}
+	// null is decoded into a nil map without errors
+	if parsed == nil {
+		return fmt.Errorf("the configuration is not a JSON object")
+	}
+	return nil
+}
+
+// MergeEmbeddedConfigurations merges several APIcast JSON configurations
+// into a single one. Top level lists, like services, are concatenated in
+// order. Any other top level key must have the same value in all the
+// configurations where it is set. Services are identified by their id,
+// which must be unique across configurations
+func MergeEmbeddedConfigurations(configurations [][]byte) ([]byte, error) {
+	merged := map[string]interface{}{}
+	serviceIDs := map[string]bool{}
+
+	for idx, configuration := range configurations {
+		parsed := map[string]interface{}{}
+		err := json.Unmarshal(configuration, &parsed)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid JSON in configuration %d: %s", idx, err)
+		}
+
+		for key, value := range parsed {
+			list, isList := value.([]interface{})
+			existing, exists := merged[key]
+			if !exists {
+				merged[key] = value
+			} else if existingList, existingIsList := existing.([]interface{}); isList && existingIsList {
+				merged[key] = append(existingList, list...)
+			} else if !reflect.DeepEqual(existing, value) {
+				return nil, fmt.Errorf("Conflicting values for key '%s' in configuration %d", key, idx)
+			}
+
+			if key == "services" && isList {
+				for _, service := range list {
+					serviceMap, ok := service.(map[string]interface{})
+					if !ok || serviceMap["id"] == nil {
+						continue
+					}
+					serviceID := fmt.Sprintf("%v", serviceMap["id"])
+					if serviceIDs[serviceID] {
+						return nil, fmt.Errorf("Duplicated service id '%s' in configuration %d", serviceID, idx)
+					}
+					serviceIDs[serviceID] = true
+				}
+			}
+		}
+	}
+
+	return json.Marshal(merged)
+}
@@ -0,0 +1,33 @@
+package apicast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEmbeddedConfiguration(t *testing.T) {
+	cases := []struct {
+		name          string
+		configuration string
+		valid         bool
+	}{
+		{"empty object", `{}`, true},
+		{"services", `{"services":[{"id":1}]}`, true},
+		{"empty", ``, false},
+		{"truncated", `{"services":[`, false},
+		{"list", `[]`, false},
+		{"string", `"config"`, false},
+		{"null", `null`, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateEmbeddedConfiguration([]byte(tc.configuration))
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -1,9 +1,43 @@
 package apicast
 
-import "github.com/3scale/apicast-operator/pkg/helper"
+import (
+	"strings"
+
+	"github.com/3scale/apicast-operator/pkg/helper"
+)
 
 const defaultImageVersion = "quay.io/3scale/apicast:nightly"
 
+// GetDefaultImageVersion returns the default image of the gateway. It is read
+// from the RELATED_IMAGE_APICAST env var of the operator, which OLM rewrites
+// to point to a mirror registry in disconnected installs, then from the
+// APICAST_IMAGE env var, falling back to the image the operator is built with
 func GetDefaultImageVersion() string {
+	if image := helper.GetEnvVar("RELATED_IMAGE_APICAST", ""); image != "" {
+		return image
+	}
 	return helper.GetEnvVar("APICAST_IMAGE", defaultImageVersion)
 }
+
+// GetDefaultImageVersionForEnvironment returns the default image for the
+// given deployment environment. It is read from the
+// RELATED_IMAGE_APICAST_<ENV> env var of the operator (i.e.
+// RELATED_IMAGE_APICAST_STAGING), then from the APICAST_IMAGE_<ENV> env var,
+// falling back to the default image when none is set
+func GetDefaultImageVersionForEnvironment(deploymentEnvironment string) string {
+	if deploymentEnvironment == "" {
+		return GetDefaultImageVersion()
+	}
+
+	suffix := "_" + strings.ToUpper(deploymentEnvironment)
+	if image := helper.GetEnvVar("RELATED_IMAGE_APICAST"+suffix, ""); image != "" {
+		return image
+	}
+
+	image := helper.GetEnvVar("APICAST_IMAGE"+suffix, "")
+	if image == "" {
+		return GetDefaultImageVersion()
+	}
+
+	return image
+}
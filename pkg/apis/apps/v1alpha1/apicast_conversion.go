@@ -0,0 +1,7 @@
+package v1alpha1
+
+// Hub marks v1alpha1 as the conversion hub of the APIcast API. The APIcast
+// resources are stored in this version, and the other versions implement
+// the conversion from and to it. It satisfies the conversion.Hub interface
+// of controller-runtime
+func (*APIcast) Hub() {}
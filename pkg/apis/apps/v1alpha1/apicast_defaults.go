@@ -0,0 +1,64 @@
+package v1alpha1
+
+// DefaultReplicas is the number of gateway replicas when it is not set in
+// the APIcast resource
+const DefaultReplicas int64 = 1
+
+// DefaultRevisionHistoryLimit is the number of old ReplicaSets of the
+// gateway Deployment retained when it is not set in the APIcast resource
+const DefaultRevisionHistoryLimit int32 = 10
+
+// DefaultProgressDeadlineSeconds is the progress deadline of the gateway
+// Deployment when it is not set in the APIcast resource. It is the
+// Kubernetes default, set so it is restored when the field is cleared
+const DefaultProgressDeadlineSeconds int32 = 600
+
+// DefaultWorkloadType is the kind of workload running the gateway pods when
+// it is not set in the APIcast resource
+const DefaultWorkloadType = WorkloadTypeDeployment
+
+// DefaultConfigurationReload is the way the embedded configuration changes
+// are picked up when it is not set in the APIcast resource
+const DefaultConfigurationReload = ConfigurationReloadRollout
+
+// DefaultResourceNamePrefix is the prefix of the names of the resources
+// created for the APIcast when it is not set in the APIcast resource
+const DefaultResourceNamePrefix = "apicast-"
+
+// SetDefaults sets the default values of the optional fields of the APIcast
+// spec that need one. Returns whether any field was set
+func (s *APIcastSpec) SetDefaults() bool {
+	changed := false
+
+	if s.Replicas == nil {
+		replicas := DefaultReplicas
+		s.Replicas = &replicas
+		changed = true
+	}
+
+	if s.RevisionHistoryLimit == nil {
+		revisionHistoryLimit := DefaultRevisionHistoryLimit
+		s.RevisionHistoryLimit = &revisionHistoryLimit
+		changed = true
+	}
+
+	if s.ProgressDeadlineSeconds == nil {
+		progressDeadlineSeconds := DefaultProgressDeadlineSeconds
+		s.ProgressDeadlineSeconds = &progressDeadlineSeconds
+		changed = true
+	}
+
+	if s.WorkloadType == nil {
+		workloadType := DefaultWorkloadType
+		s.WorkloadType = &workloadType
+		changed = true
+	}
+
+	if s.ConfigurationReload == nil {
+		configurationReload := DefaultConfigurationReload
+		s.ConfigurationReload = &configurationReload
+		changed = true
+	}
+
+	return changed
+}
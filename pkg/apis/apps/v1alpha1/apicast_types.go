@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -16,13 +17,34 @@ type APIcastSpec struct {
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
 	// +optional
+	// +kubebuilder:validation:Minimum=0
 	Replicas *int64 `json:"replicas,omitempty"`
+	// Number of old ReplicaSets of the gateway Deployment to retain to allow
+	// rollback
 	// +optional
-	AdminPortalCredentialsRef *v1.LocalObjectReference `json:"adminPortalCredentialsRef,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// Minimum number of seconds a new gateway pod has to be ready to be
+	// considered available during a rollout
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+	// Maximum number of seconds for the gateway Deployment to make progress
+	// before it is considered failed
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+	// Secret with the admin portal endpoint. It can be in a different
+	// namespace than the APIcast resource
+	// +optional
+	AdminPortalCredentialsRef *v1.SecretReference `json:"adminPortalCredentialsRef,omitempty"`
 	// +optional
 	EmbeddedConfigurationSecretRef *v1.LocalObjectReference `json:"embeddedConfigurationSecretRef,omitempty"`
 	// +optional
 	ServiceAccount *string `json:"serviceAccount,omitempty"`
+	// Whether the service account token is mounted in the APIcast pods
+	// +optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
 	// +optional
 	Image *string `json:"image,omitempty"`
 	// +optional
@@ -33,23 +55,228 @@ type APIcastSpec struct {
 	DNSResolverAddress *string `json:"dnsResolverAddress,omitempty"` // RESOLVER
 	// +optional
 	EnabledServices []string `json:"enabledServices,omitempty"` // APICAST_SERVICES_LIST
+	// Defines when the configuration is loaded: on boot, or on the first
+	// request of each service and when the cache expires
 	// +optional
 	// +kubebuilder:validation:Enum=boot,lazy
-	ConfigurationLoadMode *string `json:"configurationLoadMode,omitempty"` // APICAST_CONFIGURATION_LOADER
+	ConfigurationLoadMode *ConfigurationLoadModeType `json:"configurationLoadMode,omitempty"` // APICAST_CONFIGURATION_LOADER
 	// +optional
 	// +kubebuilder:validation:Enum=debug,info,notice,warn,error,crit,alert,emerg
 	LogLevel *string `json:"logLevel,omitempty"` // APICAST_LOG_LEVEL
+	// Log level of the OpenID Connect module, set independently of LogLevel
+	// +optional
+	// +kubebuilder:validation:Enum=debug,info,notice,warn,error,crit,alert,emerg
+	OIDCLogLevel *string `json:"oidcLogLevel,omitempty"` // APICAST_OIDC_LOG_LEVEL
 	// +optional
 	PathRoutingEnabled *bool `json:"pathRoutingEnabled,omitempty"` // APICAST_PATH_ROUTING
 	// +optional
+	PathRoutingOnly *bool `json:"pathRoutingOnly,omitempty"` // APICAST_PATH_ROUTING_ONLY
+	// +optional
 	ResponseCodesIncluded *bool `json:"responseCodesIncluded,omitempty"` // APICAST_RESPONSE_CODES
+	// Period the configuration is cached. 0 disables the cache and negative
+	// values cache it forever
 	// +optional
 	CacheConfigurationSeconds *int64 `json:"cacheConfigurationSeconds,omitempty"` // APICAST_CONFIGURATION_CACHE
+	// Maximum random delay before starting the gateway of each pod, so the
+	// periodic reloads of the configuration of the pods are spread over time.
+	// Every new pod is delayed, so it slows down rollouts and scale ups
+	// +optional
+	CacheConfigurationJitterSeconds *int64 `json:"cacheConfigurationJitterSeconds,omitempty"`
+	// Scope of the management API: disabled, status (read only health
+	// checks), policies (read only configuration) or debug (full access)
 	// +optional
 	// +kubebuilder:validation:Enum=disabled,status,policies,debug
-	ManagementAPIScope *string `json:"managementAPIScope,omitempty"` // APICAST_MANAGEMENT_API
+	ManagementAPIScope *ManagementAPIScopeType `json:"managementAPIScope,omitempty"` // APICAST_MANAGEMENT_API
 	// +optional
 	OpenSSLPeerVerificationEnabled *bool `json:"openSSLPeerVerificationEnabled,omitempty"` // OPENSSL_VERIFY
+	// +optional
+	LazyLoadServices *bool `json:"lazyLoadServices,omitempty"` // APICAST_LOAD_SERVICES_WHEN_NEEDED
+	// +optional
+	ExtendedMetrics *bool `json:"extendedMetrics,omitempty"` // APICAST_EXTENDED_METRICS
+	// +optional
+	Proxy *APIcastProxy `json:"proxy,omitempty"`
+	// +optional
+	HTTPSPort *int32 `json:"httpsPort,omitempty"` // APICAST_HTTPS_PORT
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	HTTPSVerifyDepth *int64 `json:"httpsVerifyDepth,omitempty"` // APICAST_HTTPS_VERIFY_DEPTH
+	// Pauses the rollout of the gateway Deployment, preserving the running
+	// pods, while the APIcast resource or its referenced secrets are invalid
+	// +optional
+	SafeRollout *bool `json:"safeRollout,omitempty"`
+	// Duration in seconds the gateway pods are given to finish the in-flight
+	// requests before they are killed
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// +optional
+	AccessLogSidecar *APIcastAccessLogSidecar `json:"accessLogSidecar,omitempty"`
+	// +optional
+	PreStopHook *APIcastPreStopHook `json:"preStopHook,omitempty"`
+	// +optional
+	HotReloadSidecar *APIcastHotReloadSpec `json:"hotReloadSidecar,omitempty"`
+	// +optional
+	Ports *APIcastPorts `json:"ports,omitempty"`
+	// Priority class of the gateway pods
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+	// +optional
+	InitContainers []APIcastInitContainer `json:"initContainers,omitempty"`
+	// +optional
+	ReadinessProbe *APIcastProbe `json:"readinessProbe,omitempty"`
+	// Secrets with gateway configuration merged with the one in
+	// EmbeddedConfigurationSecretRef
+	// +optional
+	AdditionalEmbeddedConfigurationSecretRefs []v1.LocalObjectReference `json:"additionalEmbeddedConfigurationSecretRefs,omitempty"`
+	// Prefix of the names of the resources created for the APIcast.
+	// Defaults to "apicast-"
+	// +optional
+	ResourceNamePrefix *string `json:"resourceNamePrefix,omitempty"`
+	// Suffix of the names of the resources created for the APIcast
+	// +optional
+	ResourceNameSuffix *string `json:"resourceNameSuffix,omitempty"`
+	// Additional volumes of the APIcast pods
+	// +optional
+	Volumes []APIcastVolume `json:"volumes,omitempty"`
+	// Additional volume mounts of the APIcast container
+	// +optional
+	VolumeMounts []v1.VolumeMount `json:"volumeMounts,omitempty"`
+	// Subset of the services loaded by the gateway
+	// +optional
+	ServicesFilter *APIcastServicesFilter `json:"servicesFilter,omitempty"`
+	// Expose the management port on a dedicated internal Service instead of
+	// the gateway Service
+	// +optional
+	ManagementServiceEnabled *bool `json:"managementServiceEnabled,omitempty"`
+	// Set the APIcast resource as owner of the referenced secrets so they are
+	// watched. Defaults to true
+	// +optional
+	AdoptReferencedSecrets *bool `json:"adoptReferencedSecrets,omitempty"`
+	// Custom policies mounted in the gateway policy load path
+	// +optional
+	CustomPolicies []APIcastCustomPolicy `json:"customPolicies,omitempty"`
+	// Directories where the gateway looks for policies, in order of
+	// precedence. Each directory has to be in the custom policies directory
+	// or in a volume mounted with volumeMounts
+	// +optional
+	PolicyLoadPath []string `json:"policyLoadPath,omitempty"` // APICAST_POLICY_LOAD_PATH
+	// Connection settings of the gateway with the upstream APIs
+	// +optional
+	Upstream *APIcastUpstream `json:"upstream,omitempty"`
+	// Settings of the reporting of the traffic to the 3scale backend
+	// +optional
+	Reporting *APIcastReporting `json:"reporting,omitempty"`
+	// Existing PersistentVolumeClaim with the gateway configuration file. It
+	// is an alternative to EmbeddedConfigurationSecretRef for configurations
+	// exceeding the size limit of the secrets
+	// +optional
+	EmbeddedConfigurationVolumeClaim *APIcastConfigurationVolumeClaim `json:"embeddedConfigurationVolumeClaim,omitempty"`
+	// Kind of the workload running the gateway pods: a Deployment with
+	// Replicas pods, or a DaemonSet with one pod per node
+	// +optional
+	// +kubebuilder:validation:Enum=Deployment,DaemonSet
+	WorkloadType *WorkloadType `json:"workloadType,omitempty"`
+	// How the gateway pods pick up the changes of the embedded configuration
+	// secret: rolling out new pods, or reloading it when the configuration
+	// cache expires
+	// +optional
+	// +kubebuilder:validation:Enum=rollout,cache
+	ConfigurationReload *ConfigurationReloadType `json:"configurationReload,omitempty"`
+	// Client certificate presented by the gateway to the upstream APIs
+	// requiring mutual TLS
+	// +optional
+	UpstreamTLS *APIcastUpstreamTLS `json:"upstreamTLS,omitempty"`
+	// Behavior of the gateway when the 3scale backend is unavailable: strict
+	// denies the requests not authorized in the cache, resilient keeps
+	// authorizing the cached applications
+	// +optional
+	// +kubebuilder:validation:Enum=strict,resilient
+	BackendCacheHandler *BackendCacheHandlerType `json:"backendCacheHandler,omitempty"` // APICAST_BACKEND_CACHE_HANDLER
+	// Type of the gateway Service. Defaults to ClusterIP
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterIP,NodePort,LoadBalancer
+	ServiceType *v1.ServiceType `json:"serviceType,omitempty"`
+	// Whether the gateway Service routes the external traffic to node-local
+	// endpoints only, preserving the client source IP. Only allowed for the
+	// NodePort and LoadBalancer Service types
+	// +optional
+	// +kubebuilder:validation:Enum=Cluster,Local
+	ExternalTrafficPolicy *v1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+	// Session affinity of the gateway Service
+	// +optional
+	// +kubebuilder:validation:Enum=None,ClientIP
+	SessionAffinity *v1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+	// Error log file of the gateway, written to the shared logs volume
+	// instead of to the standard error
+	// +optional
+	ErrorLog *APIcastErrorLog `json:"errorLog,omitempty"`
+	// Expose the management port in a Service. When false, the management
+	// API is only meant to be reached from within the gateway pods, i.e. by
+	// sidecars. Defaults to true
+	// +optional
+	ManagementPortExposed *bool `json:"managementPortExposed,omitempty"`
+	// DNS policy of the gateway pods. Defaults to ClusterFirst
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirst,ClusterFirstWithHostNet,Default,None
+	DNSPolicy *v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// DNS settings of the gateway pods, merged with the ones of the DNS
+	// policy
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// Command of the gateway container, replacing the entrypoint of the image
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Arguments of the gateway container, replacing the command of the image
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Timing of the liveness probe of the gateway container, i.e. to give
+	// time to slow-booting gateways to load a large configuration
+	// +optional
+	LivenessProbe *APIcastProbe `json:"livenessProbe,omitempty"`
+	// NetworkPolicy restricting the traffic of the gateway pods, created by
+	// the operator when set
+	// +optional
+	NetworkPolicy *APIcastNetworkPolicy `json:"networkPolicy,omitempty"`
+	// URL serving the gateway configuration, loaded instead of the one of
+	// the 3scale Porta endpoint or an embedded one
+	// +optional
+	RemoteConfiguration *APIcastRemoteConfiguration `json:"remoteConfiguration,omitempty"`
+	// Keys of the labels of the APIcast resource copied to the gateway pods,
+	// i.e. for cost allocation
+	// +optional
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
+	// Deploy the gateway pods without sending them traffic: the Service does
+	// not select them, i.e. to pre-warm a new version before switching the
+	// traffic to it
+	// +optional
+	Standby *bool `json:"standby,omitempty"`
+	// Settings of the retries of the requests to the upstream APIs by the
+	// retry policy
+	// +optional
+	UpstreamRetry *APIcastUpstreamRetry `json:"upstreamRetry,omitempty"`
+	// Annotations of the gateway Deployment or DaemonSet, i.e. for GitOps
+	// tools. They are not set in the gateway pods
+	// +optional
+	DeploymentAnnotations map[string]string `json:"deploymentAnnotations,omitempty"`
+	// Annotations of the gateway Service, i.e. the hints of the LoadBalancer
+	// of the cloud provider
+	// +optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+	// Scale the gateway Deployment down to zero replicas, keeping Replicas to
+	// restore them when it is unset. The Service and the Ingress are kept
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+	// Sidecar serving the metrics over TLS and/or with basic authentication
+	// +optional
+	SecureMetrics *APIcastSecureMetrics `json:"secureMetrics,omitempty"`
+	// Whether the operator creates and reconciles the gateway Service.
+	// Defaults to true
+	// +optional
+	ManageService *bool `json:"manageService,omitempty"`
+	// Whether the operator creates and reconciles the gateway Ingress.
+	// Defaults to true
+	// +optional
+	ManageIngress *bool `json:"manageIngress,omitempty"`
 }
 
 type DeploymentEnvironmentType string
@@ -59,6 +286,62 @@ const (
 	DeploymentEnvironmentStaging    = "staging"
 )
 
+type ConfigurationLoadModeType string
+
+const (
+	// ConfigurationLoadModeBoot loads the configuration when the gateway
+	// starts, reloading it when the configuration cache expires
+	ConfigurationLoadModeBoot ConfigurationLoadModeType = "boot"
+	// ConfigurationLoadModeLazy loads the configuration on the first request
+	// and when the configuration cache expires
+	ConfigurationLoadModeLazy ConfigurationLoadModeType = "lazy"
+)
+
+type ConfigurationReloadType string
+
+const (
+	// ConfigurationReloadRollout rolls out new gateway pods when the embedded
+	// configuration secret changes
+	ConfigurationReloadRollout ConfigurationReloadType = "rollout"
+	// ConfigurationReloadCache keeps the gateway pods, which reload the
+	// mounted configuration when the configuration cache expires
+	ConfigurationReloadCache ConfigurationReloadType = "cache"
+)
+
+type BackendCacheHandlerType string
+
+const (
+	// BackendCacheHandlerStrict removes the cached authorizations when the
+	// 3scale backend cannot be reached, so the requests are denied
+	BackendCacheHandlerStrict BackendCacheHandlerType = "strict"
+	// BackendCacheHandlerResilient keeps the cached authorizations when the
+	// 3scale backend cannot be reached, so the requests are still allowed
+	BackendCacheHandlerResilient BackendCacheHandlerType = "resilient"
+)
+
+type WorkloadType string
+
+const (
+	// WorkloadTypeDeployment runs the gateway pods in a Deployment
+	WorkloadTypeDeployment WorkloadType = "Deployment"
+	// WorkloadTypeDaemonSet runs one gateway pod per node in a DaemonSet
+	WorkloadTypeDaemonSet WorkloadType = "DaemonSet"
+)
+
+type ManagementAPIScopeType string
+
+const (
+	// ManagementAPIScopeDisabled disables the management API
+	ManagementAPIScopeDisabled ManagementAPIScopeType = "disabled"
+	// ManagementAPIScopeStatus only exposes the health check endpoints
+	ManagementAPIScopeStatus ManagementAPIScopeType = "status"
+	// ManagementAPIScopePolicies also exposes the list of policies
+	ManagementAPIScopePolicies ManagementAPIScopeType = "policies"
+	// ManagementAPIScopeDebug exposes the full API, which allows reading the
+	// configuration, including the credentials, and replacing it
+	ManagementAPIScopeDebug ManagementAPIScopeType = "debug"
+)
+
 // APIcastStatus defines the observed state of APIcast
 // +k8s:openapi-gen=true
 type APIcastStatus struct {
@@ -75,12 +358,356 @@ type APIcastStatus struct {
 	// The image being used in the APIcast deployment
 	// +optional
 	Image string `json:"image,omitempty"`
+
+	// Names of the resources managed by the operator for the APIcast
+	// +optional
+	ManagedResources *APIcastManagedResources `json:"managedResources,omitempty"`
+
+	// Names of the referenced secrets adopted by the APIcast. They are
+	// released when they are no longer referenced
+	// +optional
+	AdoptedSecrets []string `json:"adoptedSecrets,omitempty"`
+
+	// Namespace/name of the admin portal credentials secret of another
+	// namespace annotated with the APIcast. It is removed from the annotation
+	// when the secret is no longer referenced
+	// +optional
+	WatchedSecret string `json:"watchedSecret,omitempty"`
+
+	// Label selector of the APIcast pods
+	// +optional
+	PodSelector string `json:"podSelector,omitempty"`
+}
+
+// APIcastManagedResources contains the names of the resources managed by the
+// operator, in the namespace of the APIcast
+type APIcastManagedResources struct {
+	// +optional
+	Deployment string `json:"deployment,omitempty"`
+	// +optional
+	DaemonSet string `json:"daemonSet,omitempty"`
+	// +optional
+	Service string `json:"service,omitempty"`
+	// +optional
+	Ingress string `json:"ingress,omitempty"`
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// +optional
+	ManagementService string `json:"managementService,omitempty"`
+	// +optional
+	Certificate string `json:"certificate,omitempty"`
+	// +optional
+	NetworkPolicy string `json:"networkPolicy,omitempty"`
 }
 
 type APIcastExposedHost struct {
 	Host string `json:"host"`
+	// Additional domain names routed to the gateway
+	// +optional
+	AdditionalHosts []string `json:"additionalHosts,omitempty"`
 	// +optional
 	TLS []extensions.IngressTLS `json:"tls,omitempty"`
+	// Requests the TLS certificate of the exposed hosts to cert-manager
+	// +optional
+	CertManager *APIcastCertManager `json:"certManager,omitempty"`
+	// Path of the Ingress rules routed to the gateway. Defaults to all the
+	// paths
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Annotations of the Ingress, i.e. the rewrite of the path by the
+	// Ingress controller
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// APIcastCertManager defines the cert-manager Certificate requested for the
+// exposed hosts
+type APIcastCertManager struct {
+	// Name of the cert-manager ClusterIssuer signing the certificate
+	ClusterIssuer string `json:"clusterIssuer"`
+}
+
+// APIcastProxy defines the HTTP proxy the gateway uses to reach the 3scale
+// portal and the upstream APIs
+type APIcastProxy struct {
+	// +optional
+	HTTPProxy *string `json:"httpProxy,omitempty"` // HTTP_PROXY
+	// +optional
+	HTTPSProxy *string `json:"httpsProxy,omitempty"` // HTTPS_PROXY
+	// Comma separated list of hostnames, domains and CIDRs that are not proxied
+	// +optional
+	NoProxy *string `json:"noProxy,omitempty"` // NO_PROXY
+}
+
+// APIcastAccessLogSidecar writes the gateway access logs to a file on an
+// emptyDir volume shared by all the containers of the pod, so they can be
+// consumed by a log forwarder sidecar
+type APIcastAccessLogSidecar struct {
+	// Path of the access log file. It must be located under the shared logs
+	// volume, mounted at /var/log/apicast
+	// +optional
+	Path *string `json:"path,omitempty"` // APICAST_ACCESS_LOG_FILE
+	// +optional
+	Forwarder *APIcastLogForwarder `json:"forwarder,omitempty"`
+}
+
+// APIcastErrorLog writes the gateway error log to a file on the emptyDir
+// volume shared by all the containers of the pod. Its level is set by
+// LogLevel
+type APIcastErrorLog struct {
+	// Path of the error log file. It must be located under the shared logs
+	// volume, mounted at /var/log/apicast
+	// +optional
+	Path *string `json:"path,omitempty"` // APICAST_LOG_FILE
+}
+
+// APIcastLogForwarder defines the sidecar container that ships the access
+// logs written to the shared logs volume
+type APIcastLogForwarder struct {
+	Image string `json:"image"`
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// +optional
+	Args []string `json:"args,omitempty"`
+}
+
+// APIcastPreStopHook defines the preStop lifecycle hook of the gateway
+// container. It delays the shutdown of the gateway so it stops receiving new
+// connections before it is terminated during rolling updates
+type APIcastPreStopHook struct {
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Seconds the gateway keeps serving requests before receiving SIGTERM.
+	// Ignored when Command is set
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SleepSeconds *int64 `json:"sleepSeconds,omitempty"`
+	// Command executed in the gateway container instead of the sleep
+	// +optional
+	Command []string `json:"command,omitempty"`
+}
+
+// APIcastHotReloadSpec defines a sidecar container that reloads the gateway
+// when the embedded configuration changes, instead of rolling out new pods
+type APIcastHotReloadSpec struct {
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Image of the sidecar container. It has to watch the directory set in
+	// the WATCH_PATH env var and send SIGHUP to the nginx master process on
+	// changes
+	Image string `json:"image"`
+}
+
+// APIcastPorts defines the names and Service port numbers of the gateway
+// ports. The ports the gateway container listens on, targeted by the Service
+// and the probes, are fixed: 8080 (proxy), 8090 (management) and 9421
+// (metrics)
+type APIcastPorts struct {
+	// +optional
+	Proxy *APIcastPort `json:"proxy,omitempty"`
+	// +optional
+	Management *APIcastPort `json:"management,omitempty"`
+	// Metrics port. It is only added to the Service when it is set
+	// +optional
+	Metrics *APIcastPort `json:"metrics,omitempty"`
+}
+
+type APIcastPort struct {
+	// Name of the port in the Service and in the gateway container
+	// +optional
+	Name *string `json:"name,omitempty"`
+	// Service port number. It does not change the port the gateway container
+	// listens on
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+}
+
+// APIcastInitContainer defines a container that runs before the gateway
+// starts, i.e. to validate the gateway configuration. It has the same env
+// vars and volume mounts as the gateway container
+type APIcastInitContainer struct {
+	Name string `json:"name"`
+	// Image of the container. Defaults to the gateway image
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// +optional
+	Args []string `json:"args,omitempty"`
+}
+
+// APIcastProbe defines the timing of a gateway probe
+type APIcastProbe struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+	// Container port checked by the probe. Defaults to the management port,
+	// which serves the status endpoints
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+}
+
+// APIcastVolume defines an additional volume of the APIcast pods. Exactly
+// one of the volume sources has to be set
+type APIcastVolume struct {
+	Name string `json:"name"`
+	// +optional
+	Secret *v1.SecretVolumeSource `json:"secret,omitempty"`
+	// +optional
+	ConfigMap *v1.ConfigMapVolumeSource `json:"configMap,omitempty"`
+	// +optional
+	EmptyDir *APIcastEmptyDirVolumeSource `json:"emptyDir,omitempty"`
+}
+
+// APIcastEmptyDirVolumeSource defines an empty directory shared by the
+// containers of the APIcast pod
+type APIcastEmptyDirVolumeSource struct {
+	// Storage medium backing the directory. Defaults to the node default
+	// storage medium
+	// +optional
+	Medium v1.StorageMedium `json:"medium,omitempty"`
+}
+
+// APIcastServicesFilter defines the subset of the services loaded by the
+// gateway. When both are set, only the services matching both are loaded
+type APIcastServicesFilter struct {
+	// IDs of the services to load
+	// +optional
+	ServiceIDs []string `json:"serviceIDs,omitempty"` // APICAST_SERVICES_LIST
+	// Regular expression matched against the public base URL of the services
+	// +optional
+	URLFilter *string `json:"urlFilter,omitempty"` // APICAST_SERVICES_FILTER_BY_URL
+}
+
+// APIcastCustomPolicy defines a custom policy whose files are in a secret or
+// in a ConfigMap. Exactly one of them has to be set
+type APIcastCustomPolicy struct {
+	// Name of the policy
+	Name string `json:"name"`
+	// Version of the policy
+	Version string `json:"version"`
+	// Secret with the policy files
+	// +optional
+	SecretRef *v1.LocalObjectReference `json:"secretRef,omitempty"`
+	// ConfigMap with the policy files
+	// +optional
+	ConfigMapRef *v1.LocalObjectReference `json:"configMapRef,omitempty"`
+}
+
+// APIcastUpstream defines the connection settings of the gateway with the
+// upstream APIs
+type APIcastUpstream struct {
+	// Maximum number of requests served through a keepalive connection
+	// before it is closed
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	KeepaliveRequests *int32 `json:"keepaliveRequests,omitempty"` // APICAST_LUA_SOCKET_KEEPALIVE_REQUESTS
+}
+
+// APIcastUpstreamTLS defines the TLS settings of the gateway connections
+// with the upstream APIs
+type APIcastUpstreamTLS struct {
+	// Secret of type kubernetes.io/tls with the client certificate, in the
+	// tls.crt key, and its private key, in the tls.key key
+	ClientCertificateSecretRef v1.LocalObjectReference `json:"clientCertificateSecretRef"`
+}
+
+// APIcastReporting defines the settings of the reporting of the traffic to
+// the 3scale backend
+type APIcastReporting struct {
+	// Number of threads reporting the traffic asynchronously. 0 reports it
+	// in the request
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Threads *int32 `json:"threads,omitempty"` // APICAST_REPORTING_THREADS
+	// Size in MiB of the shared memory where the 3scale batcher policy
+	// accumulates the reports until they are sent in a batch
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	BatcherSharedMemorySizeMiB *int32 `json:"batcherSharedMemorySizeMiB,omitempty"` // APICAST_POLICY_BATCHER_SHARED_MEMORY_SIZE
+}
+
+// APIcastConfigurationVolumeClaim references the PersistentVolumeClaim with
+// the gateway configuration file
+type APIcastConfigurationVolumeClaim struct {
+	// Name of the PersistentVolumeClaim, in the namespace of the APIcast
+	ClaimName string `json:"claimName"`
+	// Path of the configuration file, relative to the root of the volume.
+	// Defaults to "config.json"
+	// +optional
+	Path *string `json:"path,omitempty"`
+}
+
+// APIcastNetworkPolicy defines the sources and the destinations allowed by
+// the NetworkPolicy of the gateway pods
+type APIcastNetworkPolicy struct {
+	// Sources allowed to connect to the proxy ports, i.e. the pods of the
+	// ingress controller. Defaults to any source
+	// +optional
+	ProxyFrom []networkingv1.NetworkPolicyPeer `json:"proxyFrom,omitempty"`
+	// Sources allowed to connect to the management and metrics ports.
+	// Defaults to the pods of the namespace
+	// +optional
+	ManagementFrom []networkingv1.NetworkPolicyPeer `json:"managementFrom,omitempty"`
+	// Destinations the gateway pods can connect to, i.e. the 3scale backend
+	// and the upstream APIs. DNS is always allowed. When not set, the egress
+	// traffic is not restricted
+	// +optional
+	Egress []networkingv1.NetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// APIcastRemoteConfiguration defines a URL serving the gateway configuration
+type APIcastRemoteConfiguration struct {
+	// URL of the gateway configuration. Only the http and https schemes are
+	// supported
+	URL string `json:"url"` // APICAST_CONFIGURATION
+	// Secret with the credentials sent with HTTP basic authentication, in
+	// the username and password keys
+	// +optional
+	CredentialsSecretRef *v1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// APIcastUpstreamRetry defines the retries of the requests to the upstream
+// APIs. They are only retried by the services with the retry policy in
+// their policy chain, which sets the number of retries
+type APIcastUpstreamRetry struct {
+	// Cases in which a request is retried with the next upstream server, in
+	// the format of the proxy_next_upstream directive of NGINX, i.e.
+	// "error", "timeout", "http_502" or "http_503"
+	Cases []string `json:"cases"` // APICAST_UPSTREAM_RETRY_CASES
+}
+
+// APIcastSecureMetrics defines the sidecar container serving the metrics of
+// the gateway over TLS and/or with basic authentication
+type APIcastSecureMetrics struct {
+	// Image of the sidecar container. It has to listen in the port set in
+	// the SECURE_METRICS_PORT env var and proxy the requests to the URL set
+	// in the METRICS_UPSTREAM env var
+	Image string `json:"image"`
+	// Secret of type kubernetes.io/tls with the certificate of the metrics
+	// endpoint, in the tls.crt key, and its private key, in the tls.key key
+	// +optional
+	TLSSecretRef *v1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+	// Secret of type kubernetes.io/basic-auth with the credentials required
+	// to scrape the metrics, in the username and password keys
+	// +optional
+	BasicAuthSecretRef *v1.LocalObjectReference `json:"basicAuthSecretRef,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -88,6 +715,7 @@ type APIcastExposedHost struct {
 // APIcast is the Schema for the apicasts API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 type APIcast struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -98,24 +726,97 @@ type APIcast struct {
 
 type APIcastConditionType string
 
+const (
+	// RolloutPausedConditionType means the rollout of the gateway Deployment
+	// has been paused by the safe rollout mode because the APIcast resource
+	// failed validation
+	RolloutPausedConditionType APIcastConditionType = "RolloutPaused"
+	// InvalidConditionType means the APIcast resource or its referenced
+	// secrets failed validation and the gateway resources are not being
+	// reconciled
+	InvalidConditionType APIcastConditionType = "Invalid"
+	// SecretResolvedConditionType means the secrets referenced by the APIcast
+	// resource exist and have the required keys. When its status is False,
+	// the message tells which secret or key is missing
+	SecretResolvedConditionType APIcastConditionType = "SecretResolved"
+	// PausedConditionType means the reconciliation of the resources owned by
+	// the APIcast resource is paused by the paused annotation
+	PausedConditionType APIcastConditionType = "Paused"
+	// DegradedConditionType means the rollout of the gateway Deployment is
+	// stuck or it does not have the minimum available replicas, i.e. because
+	// the image cannot be pulled or the gateway container keeps crashing
+	DegradedConditionType APIcastConditionType = "Degraded"
+	// ScaledDownConditionType means the gateway Deployment is scaled down to
+	// zero replicas on purpose, because Replicas is set to 0
+	ScaledDownConditionType APIcastConditionType = "ScaledDown"
+	// SuspendedConditionType means the gateway Deployment is scaled down to
+	// zero replicas because Suspend is set
+	SuspendedConditionType APIcastConditionType = "Suspended"
+)
+
 type APIcastCondition struct {
 	// Type of replica set condition.
 	Type APIcastConditionType `json:"type"`
 	// Status of the condition, one of True, False, Unknown.
 	Status v1.ConditionStatus `json:"status"`
 
-	// The Reason, Message, LastHeartbeatTime and LastTransitionTime fields are
-	// optional. Unless we really use them they should directly not be used even
+	// The LastHeartbeatTime and LastTransitionTime fields are optional.
+	// Unless we really use them they should directly not be used even
 	// if they are optional
 	// The last time the condition transitioned from one status to another.
 	// +optional
 	//LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 	// The reason for the condition's last transition.
 	// +optional
-	//Reason string `json:"reason,omitempty"`
+	Reason string `json:"reason,omitempty"`
 	// A human readable message indicating details about the transition.
 	// +optional
-	//Message string `json:"message,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// GetCondition returns the condition with the given type, or nil if it is
+// not set
+func (s *APIcastStatus) GetCondition(conditionType APIcastConditionType) *APIcastCondition {
+	for idx := range s.Conditions {
+		if s.Conditions[idx].Type == conditionType {
+			return &s.Conditions[idx]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns whether the condition with the given type is set
+// and its status is True
+func (s *APIcastStatus) IsConditionTrue(conditionType APIcastConditionType) bool {
+	condition := s.GetCondition(conditionType)
+	return condition != nil && condition.Status == v1.ConditionTrue
+}
+
+// SetCondition adds the condition, replacing the existing one with the same
+// type. Returns whether the conditions changed
+func (s *APIcastStatus) SetCondition(condition APIcastCondition) bool {
+	existing := s.GetCondition(condition.Type)
+	if existing == nil {
+		s.Conditions = append(s.Conditions, condition)
+		return true
+	}
+	if *existing == condition {
+		return false
+	}
+	*existing = condition
+	return true
+}
+
+// RemoveCondition removes the condition with the given type. Returns whether
+// the conditions changed
+func (s *APIcastStatus) RemoveCondition(conditionType APIcastConditionType) bool {
+	for idx := range s.Conditions {
+		if s.Conditions[idx].Type == conditionType {
+			s.Conditions = append(s.Conditions[:idx], s.Conditions[idx+1:]...)
+			return true
+		}
+	}
+	return false
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,671 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/3scale/apicast-operator/pkg/apicast"
+	v1 "k8s.io/api/core/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// LogLevels are the log levels accepted by APIcast
+var LogLevels = []string{
+	"debug", "info", "notice", "warn", "error", "crit", "alert", "emerg",
+}
+
+// ConfigurationLoadModes are the configuration load modes accepted by APIcast
+var ConfigurationLoadModes = []string{
+	string(ConfigurationLoadModeBoot), string(ConfigurationLoadModeLazy),
+}
+
+// ConfigurationReloads are the ways the embedded configuration changes can be
+// picked up by the gateway pods
+var ConfigurationReloads = []string{
+	string(ConfigurationReloadRollout), string(ConfigurationReloadCache),
+}
+
+// BackendCacheHandlers are the backend cache handlers accepted by APIcast
+var BackendCacheHandlers = []string{
+	string(BackendCacheHandlerStrict), string(BackendCacheHandlerResilient),
+}
+
+// ReservedAnnotationPrefix is the prefix of the annotations set by the
+// operator, that cannot be set in the annotations of the resources owned by
+// the APIcast
+const ReservedAnnotationPrefix = "apicast.apps.3scale.net/"
+
+// UpstreamRetryCases are the cases in which APIcast can retry the requests
+// to the upstream APIs
+var UpstreamRetryCases = []string{
+	"error", "timeout", "invalid_header", "http_500", "http_502", "http_503", "http_504",
+	"http_403", "http_404", "http_429", "non_idempotent", "off",
+}
+
+// ServiceTypes are the types of the gateway Service
+var ServiceTypes = []string{
+	string(v1.ServiceTypeClusterIP), string(v1.ServiceTypeNodePort), string(v1.ServiceTypeLoadBalancer),
+}
+
+// ExternalTrafficPolicies are the external traffic policies of the gateway
+// Service
+var ExternalTrafficPolicies = []string{
+	string(v1.ServiceExternalTrafficPolicyTypeCluster), string(v1.ServiceExternalTrafficPolicyTypeLocal),
+}
+
+// SessionAffinities are the session affinities of the gateway Service
+var SessionAffinities = []string{
+	string(v1.ServiceAffinityNone), string(v1.ServiceAffinityClientIP),
+}
+
+// WorkloadTypes are the kinds of workload that can run the gateway pods
+var WorkloadTypes = []string{
+	string(WorkloadTypeDeployment), string(WorkloadTypeDaemonSet),
+}
+
+// ManagementAPIScopes are the management API scopes accepted by APIcast
+var ManagementAPIScopes = []string{
+	string(ManagementAPIScopeDisabled), string(ManagementAPIScopeStatus),
+	string(ManagementAPIScopePolicies), string(ManagementAPIScopeDebug),
+}
+
+// MinCacheConfigurationSeconds is the minimum positive period accepted by
+// APIcast to cache the configuration. Negative values cache it forever and
+// 0 disables the cache
+const MinCacheConfigurationSeconds = 60
+
+// ResourceName returns the name of the resources created for the APIcast
+func (a *APIcast) ResourceName() string {
+	prefix := DefaultResourceNamePrefix
+	if a.Spec.ResourceNamePrefix != nil {
+		prefix = *a.Spec.ResourceNamePrefix
+	}
+
+	suffix := ""
+	if a.Spec.ResourceNameSuffix != nil {
+		suffix = *a.Spec.ResourceNameSuffix
+	}
+
+	return prefix + a.Name + suffix
+}
+
+// Validate returns the errors found in the APIcast spec, and in the name of
+// the resources built from the name of the APIcast
+func (a *APIcast) Validate() field.ErrorList {
+	errs := a.Spec.Validate()
+
+	// The name is also used as container name and label value, which are
+	// more restrictive than resource names
+	resourceName := a.ResourceName()
+	specPath := field.NewPath("spec")
+	for _, msg := range validation.IsDNS1123Label(resourceName) {
+		errs = append(errs, field.Invalid(specPath.Child("resourceNamePrefix"), resourceName, fmt.Sprintf("built from %s, metadata.name and %s: %s", specPath.Child("resourceNamePrefix"), specPath.Child("resourceNameSuffix"), msg)))
+	}
+
+	return errs
+}
+
+// Validate returns the errors found in the fields of the APIcast spec that
+// can be checked without reading the referenced secrets
+func (s *APIcastSpec) Validate() field.ErrorList {
+	errs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+
+	if s.Replicas != nil && (*s.Replicas < 0 || *s.Replicas > math.MaxInt32) {
+		errs = append(errs, field.Invalid(specPath.Child("replicas"), *s.Replicas, fmt.Sprintf("must be between 0 and %d", math.MaxInt32)))
+	}
+
+	if s.RevisionHistoryLimit != nil && *s.RevisionHistoryLimit < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("revisionHistoryLimit"), *s.RevisionHistoryLimit, "must be greater than or equal to 0"))
+	}
+
+	if s.MinReadySeconds != nil && *s.MinReadySeconds < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("minReadySeconds"), *s.MinReadySeconds, "must be greater than or equal to 0"))
+	}
+
+	if s.ProgressDeadlineSeconds != nil {
+		minReadySeconds := int32(0)
+		if s.MinReadySeconds != nil {
+			minReadySeconds = *s.MinReadySeconds
+		}
+		if *s.ProgressDeadlineSeconds <= minReadySeconds {
+			errs = append(errs, field.Invalid(specPath.Child("progressDeadlineSeconds"), *s.ProgressDeadlineSeconds, "must be greater than minReadySeconds"))
+		}
+	}
+
+	// Kubernetes rejects liveness probes with other success thresholds
+	if s.LivenessProbe != nil && s.LivenessProbe.SuccessThreshold != nil && *s.LivenessProbe.SuccessThreshold != 1 {
+		errs = append(errs, field.Invalid(specPath.Child("livenessProbe", "successThreshold"), *s.LivenessProbe.SuccessThreshold, "must be 1"))
+	}
+
+	if s.ManagementPortExposed != nil && !*s.ManagementPortExposed && s.ManagementServiceEnabled != nil && *s.ManagementServiceEnabled {
+		errs = append(errs, field.Forbidden(specPath.Child("managementServiceEnabled"), fmt.Sprintf("cannot be set together with %s false", specPath.Child("managementPortExposed"))))
+	}
+
+	errs = append(errs, validateAnnotations(s.DeploymentAnnotations, specPath.Child("deploymentAnnotations"))...)
+	errs = append(errs, validateAnnotations(s.ServiceAnnotations, specPath.Child("serviceAnnotations"))...)
+
+	for idx, key := range s.PropagateLabels {
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, field.Invalid(specPath.Child("propagateLabels").Index(idx), key, msg))
+		}
+	}
+
+	if len(s.Command) > 0 && strings.TrimSpace(s.Command[0]) == "" {
+		errs = append(errs, field.Invalid(specPath.Child("command").Index(0), s.Command[0], "must not be empty"))
+	}
+
+	if s.DNSPolicy != nil && *s.DNSPolicy == v1.DNSNone && (s.DNSConfig == nil || len(s.DNSConfig.Nameservers) == 0) {
+		errs = append(errs, field.Required(specPath.Child("dnsConfig", "nameservers"), fmt.Sprintf("at least one nameserver is required when %s is '%s'", specPath.Child("dnsPolicy"), v1.DNSNone)))
+	}
+
+	if s.ServiceType != nil && !containsString(ServiceTypes, string(*s.ServiceType)) {
+		errs = append(errs, field.NotSupported(specPath.Child("serviceType"), *s.ServiceType, ServiceTypes))
+	}
+
+	if s.ExternalTrafficPolicy != nil {
+		externalTrafficPolicyPath := specPath.Child("externalTrafficPolicy")
+		if !containsString(ExternalTrafficPolicies, string(*s.ExternalTrafficPolicy)) {
+			errs = append(errs, field.NotSupported(externalTrafficPolicyPath, *s.ExternalTrafficPolicy, ExternalTrafficPolicies))
+		}
+		if s.ServiceType == nil || *s.ServiceType == v1.ServiceTypeClusterIP {
+			errs = append(errs, field.Forbidden(externalTrafficPolicyPath, fmt.Sprintf("requires %s to be '%s' or '%s'", specPath.Child("serviceType"), v1.ServiceTypeNodePort, v1.ServiceTypeLoadBalancer)))
+		}
+	}
+
+	if s.SessionAffinity != nil && !containsString(SessionAffinities, string(*s.SessionAffinity)) {
+		errs = append(errs, field.NotSupported(specPath.Child("sessionAffinity"), *s.SessionAffinity, SessionAffinities))
+	}
+
+	if s.BackendCacheHandler != nil && !containsString(BackendCacheHandlers, string(*s.BackendCacheHandler)) {
+		errs = append(errs, field.NotSupported(specPath.Child("backendCacheHandler"), *s.BackendCacheHandler, BackendCacheHandlers))
+	}
+
+	if s.ConfigurationReload != nil {
+		configurationReloadPath := specPath.Child("configurationReload")
+		if !containsString(ConfigurationReloads, string(*s.ConfigurationReload)) {
+			errs = append(errs, field.NotSupported(configurationReloadPath, *s.ConfigurationReload, ConfigurationReloads))
+		}
+		if *s.ConfigurationReload == ConfigurationReloadCache {
+			if s.EmbeddedConfigurationSecretRef == nil {
+				errs = append(errs, field.Required(specPath.Child("embeddedConfigurationSecretRef"), fmt.Sprintf("required when %s is '%s'", configurationReloadPath, ConfigurationReloadCache)))
+			}
+			if s.CacheConfigurationSeconds == nil || *s.CacheConfigurationSeconds <= 0 {
+				errs = append(errs, field.Required(specPath.Child("cacheConfigurationSeconds"), fmt.Sprintf("must be greater than 0 when %s is '%s'", configurationReloadPath, ConfigurationReloadCache)))
+			}
+			if s.ConfigurationLoadMode == nil || *s.ConfigurationLoadMode != ConfigurationLoadModeLazy {
+				errs = append(errs, field.Required(specPath.Child("configurationLoadMode"), fmt.Sprintf("must be '%s' when %s is '%s'", ConfigurationLoadModeLazy, configurationReloadPath, ConfigurationReloadCache)))
+			}
+			if s.HotReloadSidecar != nil && s.HotReloadSidecar.Enabled != nil && *s.HotReloadSidecar.Enabled {
+				errs = append(errs, field.Forbidden(specPath.Child("hotReloadSidecar", "enabled"), fmt.Sprintf("cannot be set together with %s '%s'", configurationReloadPath, ConfigurationReloadCache)))
+			}
+		}
+	}
+
+	if s.WorkloadType != nil {
+		workloadTypePath := specPath.Child("workloadType")
+		if !containsString(WorkloadTypes, string(*s.WorkloadType)) {
+			errs = append(errs, field.NotSupported(workloadTypePath, *s.WorkloadType, WorkloadTypes))
+		}
+		// The safe rollout mode pauses the rollout, which DaemonSets do not support
+		if *s.WorkloadType == WorkloadTypeDaemonSet && s.SafeRollout != nil && *s.SafeRollout {
+			errs = append(errs, field.Forbidden(workloadTypePath, fmt.Sprintf("'%s' cannot be set together with %s", WorkloadTypeDaemonSet, specPath.Child("safeRollout"))))
+		}
+
+		if *s.WorkloadType == WorkloadTypeDaemonSet && s.Suspend != nil && *s.Suspend {
+			errs = append(errs, field.Forbidden(workloadTypePath, fmt.Sprintf("'%s' cannot be set together with %s", WorkloadTypeDaemonSet, specPath.Child("suspend"))))
+		}
+	}
+
+	// The standby gateway is removed from the endpoints by the selector of the
+	// Service, and the exposed hosts are routed by the Ingress
+	if s.ManageService != nil && !*s.ManageService && s.Standby != nil && *s.Standby {
+		errs = append(errs, field.Forbidden(specPath.Child("manageService"), fmt.Sprintf("false cannot be set together with %s", specPath.Child("standby"))))
+	}
+	if s.ManageIngress != nil && !*s.ManageIngress && s.ExposedHost != nil {
+		errs = append(errs, field.Forbidden(specPath.Child("manageIngress"), fmt.Sprintf("false cannot be set together with %s", specPath.Child("exposedHost"))))
+	}
+
+	if s.LogLevel != nil && !containsString(LogLevels, *s.LogLevel) {
+		errs = append(errs, field.NotSupported(specPath.Child("logLevel"), *s.LogLevel, LogLevels))
+	}
+
+	if s.ConfigurationLoadMode != nil && !containsString(ConfigurationLoadModes, string(*s.ConfigurationLoadMode)) {
+		errs = append(errs, field.NotSupported(specPath.Child("configurationLoadMode"), *s.ConfigurationLoadMode, ConfigurationLoadModes))
+	}
+
+	if s.OIDCLogLevel != nil && !containsString(LogLevels, *s.OIDCLogLevel) {
+		errs = append(errs, field.NotSupported(specPath.Child("oidcLogLevel"), *s.OIDCLogLevel, LogLevels))
+	}
+
+	// Path routing only mode takes precedence over the path routing fallback,
+	// so disabling path routing at the same time is contradictory
+	if s.PathRoutingOnly != nil && *s.PathRoutingOnly && s.PathRoutingEnabled != nil && !*s.PathRoutingEnabled {
+		errs = append(errs, field.Invalid(specPath.Child("pathRoutingEnabled"), *s.PathRoutingEnabled, "must not be false when pathRoutingOnly is true"))
+	}
+
+	if s.ManagementAPIScope != nil && !containsString(ManagementAPIScopes, string(*s.ManagementAPIScope)) {
+		errs = append(errs, field.NotSupported(specPath.Child("managementAPIScope"), *s.ManagementAPIScope, ManagementAPIScopes))
+	}
+
+	// The debug scope exposes the configuration, including the credentials,
+	// so it is not allowed on gateways reachable from outside the cluster
+	if s.ManagementAPIScope != nil && *s.ManagementAPIScope == ManagementAPIScopeDebug && s.ExposedHost != nil {
+		errs = append(errs, field.Forbidden(specPath.Child("managementAPIScope"), fmt.Sprintf("'%s' cannot be set together with %s", ManagementAPIScopeDebug, specPath.Child("exposedHost"))))
+	}
+
+	if s.CacheConfigurationSeconds != nil {
+		cachePath := specPath.Child("cacheConfigurationSeconds")
+		cacheSeconds := *s.CacheConfigurationSeconds
+		if cacheSeconds > 0 && cacheSeconds < MinCacheConfigurationSeconds {
+			errs = append(errs, field.Invalid(cachePath, cacheSeconds, fmt.Sprintf("must be negative, 0 or at least %d", MinCacheConfigurationSeconds)))
+		}
+		if cacheSeconds == 0 && s.ConfigurationLoadMode != nil && *s.ConfigurationLoadMode == ConfigurationLoadModeBoot {
+			errs = append(errs, field.Forbidden(cachePath, fmt.Sprintf("0 disables the cache, which is not compatible with %s 'boot'", specPath.Child("configurationLoadMode"))))
+		}
+	}
+
+	if s.CacheConfigurationJitterSeconds != nil {
+		errs = append(errs, validateCacheConfigurationJitter(s, specPath)...)
+	}
+
+	if s.Upstream != nil && s.Upstream.KeepaliveRequests != nil && *s.Upstream.KeepaliveRequests < 1 {
+		errs = append(errs, field.Invalid(specPath.Child("upstream", "keepaliveRequests"), *s.Upstream.KeepaliveRequests, "must be greater than 0"))
+	}
+
+	if s.UpstreamRetry != nil {
+		errs = append(errs, validateUpstreamRetry(s.UpstreamRetry, specPath.Child("upstreamRetry"))...)
+	}
+
+	if s.Reporting != nil && s.Reporting.Threads != nil && *s.Reporting.Threads < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("reporting", "threads"), *s.Reporting.Threads, "must be greater than or equal to 0"))
+	}
+
+	if s.Reporting != nil && s.Reporting.BatcherSharedMemorySizeMiB != nil && *s.Reporting.BatcherSharedMemorySizeMiB < 1 {
+		errs = append(errs, field.Invalid(specPath.Child("reporting", "batcherSharedMemorySizeMiB"), *s.Reporting.BatcherSharedMemorySizeMiB, "must be greater than 0"))
+	}
+
+	configurationSources := 0
+	if s.AdminPortalCredentialsRef != nil {
+		configurationSources++
+	}
+	if s.EmbeddedConfigurationSecretRef != nil {
+		configurationSources++
+	}
+	if s.EmbeddedConfigurationVolumeClaim != nil {
+		configurationSources++
+	}
+	if s.RemoteConfiguration != nil {
+		configurationSources++
+	}
+
+	if configurationSources == 0 {
+		errs = append(errs, field.Required(specPath, fmt.Sprintf("one of %s is required", configurationSourceFields(specPath))))
+	}
+
+	if configurationSources > 1 {
+		errs = append(errs, field.Forbidden(specPath, fmt.Sprintf("only one of %s can be set", configurationSourceFields(specPath))))
+	}
+
+	if s.EmbeddedConfigurationVolumeClaim != nil {
+		volumeClaimPath := specPath.Child("embeddedConfigurationVolumeClaim")
+		if s.EmbeddedConfigurationVolumeClaim.ClaimName == "" {
+			errs = append(errs, field.Required(volumeClaimPath.Child("claimName"), ""))
+		}
+		if s.EmbeddedConfigurationVolumeClaim.Path != nil && !isRelativeFilePath(*s.EmbeddedConfigurationVolumeClaim.Path) {
+			errs = append(errs, field.Invalid(volumeClaimPath.Child("path"), *s.EmbeddedConfigurationVolumeClaim.Path, "must be a relative file path not containing '..'"))
+		}
+	}
+
+	if s.RemoteConfiguration != nil {
+		remoteConfigurationPath := specPath.Child("remoteConfiguration")
+		if configurationURL, err := url.Parse(s.RemoteConfiguration.URL); err != nil {
+			errs = append(errs, field.Invalid(remoteConfigurationPath.Child("url"), s.RemoteConfiguration.URL, err.Error()))
+		} else if (configurationURL.Scheme != "http" && configurationURL.Scheme != "https") || configurationURL.Host == "" {
+			errs = append(errs, field.Invalid(remoteConfigurationPath.Child("url"), s.RemoteConfiguration.URL, "must be an http or https URL"))
+		} else if configurationURL.User != nil {
+			// The URL is not secret, the credentials are read from a secret
+			errs = append(errs, field.Forbidden(remoteConfigurationPath.Child("url"), fmt.Sprintf("must not contain credentials, set %s instead", remoteConfigurationPath.Child("credentialsSecretRef"))))
+		}
+		if s.RemoteConfiguration.CredentialsSecretRef != nil && s.RemoteConfiguration.CredentialsSecretRef.Name == "" {
+			errs = append(errs, field.Required(remoteConfigurationPath.Child("credentialsSecretRef", "name"), ""))
+		}
+	}
+
+	if len(s.AdditionalEmbeddedConfigurationSecretRefs) > 0 && s.EmbeddedConfigurationSecretRef == nil {
+		errs = append(errs, field.Required(specPath.Child("embeddedConfigurationSecretRef"), fmt.Sprintf("required when %s is set", specPath.Child("additionalEmbeddedConfigurationSecretRefs"))))
+	}
+
+	if s.ExposedHost != nil {
+		exposedHostPath := specPath.Child("exposedHost")
+		if s.ExposedHost.Path != "" && !strings.HasPrefix(s.ExposedHost.Path, "/") {
+			errs = append(errs, field.Invalid(exposedHostPath.Child("path"), s.ExposedHost.Path, "must start with '/'"))
+		}
+		errs = append(errs, validateAnnotations(s.ExposedHost.Annotations, exposedHostPath.Child("annotations"))...)
+		errs = append(errs, validateExposedHostNames(s.ExposedHost, exposedHostPath)...)
+	}
+
+	if s.ExposedHost != nil && s.ExposedHost.CertManager != nil {
+		certManagerPath := specPath.Child("exposedHost", "certManager")
+		if s.ExposedHost.CertManager.ClusterIssuer == "" {
+			errs = append(errs, field.Required(certManagerPath.Child("clusterIssuer"), ""))
+		}
+		if len(s.ExposedHost.TLS) > 0 {
+			errs = append(errs, field.Forbidden(certManagerPath, fmt.Sprintf("cannot be set together with %s", specPath.Child("exposedHost", "tls"))))
+		}
+	}
+
+	if s.SecureMetrics != nil {
+		secureMetricsPath := specPath.Child("secureMetrics")
+		if s.SecureMetrics.Image == "" {
+			errs = append(errs, field.Required(secureMetricsPath.Child("image"), ""))
+		}
+		if s.SecureMetrics.TLSSecretRef == nil && s.SecureMetrics.BasicAuthSecretRef == nil {
+			errs = append(errs, field.Required(secureMetricsPath, "at least one of tlsSecretRef and basicAuthSecretRef must be set"))
+		}
+		if s.SecureMetrics.TLSSecretRef != nil && s.SecureMetrics.TLSSecretRef.Name == "" {
+			errs = append(errs, field.Required(secureMetricsPath.Child("tlsSecretRef", "name"), ""))
+		}
+		if s.SecureMetrics.BasicAuthSecretRef != nil && s.SecureMetrics.BasicAuthSecretRef.Name == "" {
+			errs = append(errs, field.Required(secureMetricsPath.Child("basicAuthSecretRef", "name"), ""))
+		}
+	}
+
+	if s.UpstreamTLS != nil && s.UpstreamTLS.ClientCertificateSecretRef.Name == "" {
+		errs = append(errs, field.Required(specPath.Child("upstreamTLS", "clientCertificateSecretRef", "name"), ""))
+	}
+
+	if s.ServicesFilter != nil {
+		servicesFilterPath := specPath.Child("servicesFilter")
+		if len(s.ServicesFilter.ServiceIDs) > 0 && len(s.EnabledServices) > 0 {
+			errs = append(errs, field.Forbidden(servicesFilterPath.Child("serviceIDs"), fmt.Sprintf("cannot be set together with %s", specPath.Child("enabledServices"))))
+		}
+		if s.ServicesFilter.URLFilter != nil {
+			if _, err := regexp.Compile(*s.ServicesFilter.URLFilter); err != nil {
+				errs = append(errs, field.Invalid(servicesFilterPath.Child("urlFilter"), *s.ServicesFilter.URLFilter, err.Error()))
+			}
+		}
+	}
+
+	customPolicies := map[string]bool{}
+	for idx, customPolicy := range s.CustomPolicies {
+		customPolicyPath := specPath.Child("customPolicies").Index(idx)
+		if (customPolicy.SecretRef == nil) == (customPolicy.ConfigMapRef == nil) {
+			errs = append(errs, field.Invalid(customPolicyPath, customPolicy.Name, fmt.Sprintf("exactly one of %s, %s must be set", customPolicyPath.Child("secretRef"), customPolicyPath.Child("configMapRef"))))
+		}
+		// They are used as directory names of the policy mount path
+		if !isDirectoryName(customPolicy.Name) {
+			errs = append(errs, field.Invalid(customPolicyPath.Child("name"), customPolicy.Name, "must be a valid directory name"))
+		}
+		if !isDirectoryName(customPolicy.Version) {
+			errs = append(errs, field.Invalid(customPolicyPath.Child("version"), customPolicy.Version, "must be a valid directory name"))
+		}
+		policyID := customPolicy.Name + "/" + customPolicy.Version
+		if customPolicies[policyID] {
+			errs = append(errs, field.Duplicate(customPolicyPath, policyID))
+		}
+		customPolicies[policyID] = true
+	}
+
+	policyLoadPath := map[string]bool{}
+	for idx, directory := range s.PolicyLoadPath {
+		directoryPath := specPath.Child("policyLoadPath").Index(idx)
+		// The directories are joined with ':' in APICAST_POLICY_LOAD_PATH
+		if !strings.HasPrefix(directory, "/") || strings.Contains(directory, ":") {
+			errs = append(errs, field.Invalid(directoryPath, directory, "must be an absolute path not containing ':'"))
+			continue
+		}
+		cleanDirectory := path.Clean(directory)
+		if policyLoadPath[cleanDirectory] {
+			errs = append(errs, field.Duplicate(directoryPath, directory))
+		}
+		policyLoadPath[cleanDirectory] = true
+		if !isPolicyDirectoryMounted(cleanDirectory, s) {
+			errs = append(errs, field.Invalid(directoryPath, directory, fmt.Sprintf("must be located in the custom policies mount path '%s' or in the mount path of %s", apicast.CustomPoliciesMountPath, specPath.Child("volumeMounts"))))
+		}
+	}
+
+	errs = append(errs, validatePod(s, specPath)...)
+
+	return errs
+}
+
+// validatePod validates the fields of the APIcast spec added to the gateway
+// pods, which cannot conflict with the containers and volumes added by the
+// operator
+func validatePod(s *APIcastSpec, specPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if s.PreStopHook != nil && s.PreStopHook.Enabled != nil && *s.PreStopHook.Enabled && s.PreStopHook.Command == nil {
+		sleepSeconds := apicast.DefaultPreStopSleepSeconds
+		if s.PreStopHook.SleepSeconds != nil {
+			sleepSeconds = *s.PreStopHook.SleepSeconds
+		}
+		terminationGracePeriodSeconds := int64(v1.DefaultTerminationGracePeriodSeconds)
+		if s.TerminationGracePeriodSeconds != nil {
+			terminationGracePeriodSeconds = *s.TerminationGracePeriodSeconds
+		}
+		if sleepSeconds >= terminationGracePeriodSeconds {
+			errs = append(errs, field.Invalid(specPath.Child("preStopHook", "sleepSeconds"), sleepSeconds, fmt.Sprintf("must be less than the termination grace period (%d seconds)", terminationGracePeriodSeconds)))
+		}
+	}
+
+	accessLogFile := ""
+	if s.AccessLogSidecar != nil {
+		accessLogFile = apicast.DefaultAccessLogFile
+		if s.AccessLogSidecar.Path != nil {
+			accessLogFile = path.Clean(*s.AccessLogSidecar.Path)
+			if !isLogsVolumeFile(accessLogFile) {
+				errs = append(errs, field.Invalid(specPath.Child("accessLogSidecar", "path"), *s.AccessLogSidecar.Path, fmt.Sprintf("must be located under the shared logs volume mount path '%s'", apicast.AccessLogsMountPath)))
+			}
+		}
+	}
+
+	if s.ErrorLog != nil {
+		errorLogPath := specPath.Child("errorLog", "path")
+		errorLogFile := apicast.DefaultErrorLogFile
+		if s.ErrorLog.Path != nil {
+			errorLogFile = path.Clean(*s.ErrorLog.Path)
+			if !isLogsVolumeFile(errorLogFile) {
+				errs = append(errs, field.Invalid(errorLogPath, *s.ErrorLog.Path, fmt.Sprintf("must be located under the shared logs volume mount path '%s'", apicast.AccessLogsMountPath)))
+			}
+		}
+		if errorLogFile == accessLogFile {
+			errs = append(errs, field.Invalid(errorLogPath, errorLogFile, fmt.Sprintf("must be different from %s", specPath.Child("accessLogSidecar", "path"))))
+		}
+	}
+
+	for idx, initContainer := range s.InitContainers {
+		if apicast.IsReservedInitContainerName(initContainer.Name) {
+			errs = append(errs, field.Invalid(specPath.Child("initContainers").Index(idx).Child("name"), initContainer.Name, "is reserved for the init containers added by the operator"))
+		}
+	}
+
+	for idx, volume := range s.Volumes {
+		volumePath := specPath.Child("volumes").Index(idx)
+		if apicast.IsReservedVolumeName(volume.Name) {
+			errs = append(errs, field.Invalid(volumePath.Child("name"), volume.Name, "is reserved for the volumes managed by the operator"))
+		}
+		sources := 0
+		if volume.Secret != nil {
+			sources++
+		}
+		if volume.ConfigMap != nil {
+			sources++
+		}
+		if volume.EmptyDir != nil {
+			sources++
+		}
+		if sources != 1 {
+			errs = append(errs, field.Invalid(volumePath, volume.Name, fmt.Sprintf("exactly one of %s, %s, %s must be set", volumePath.Child("secret"), volumePath.Child("configMap"), volumePath.Child("emptyDir"))))
+		}
+	}
+
+	for idx, volumeMount := range s.VolumeMounts {
+		if apicast.IsReservedVolumeName(volumeMount.Name) {
+			errs = append(errs, field.Invalid(specPath.Child("volumeMounts").Index(idx).Child("name"), volumeMount.Name, "is reserved for the volumes managed by the operator"))
+		}
+	}
+
+	if s.HotReloadSidecar != nil && s.HotReloadSidecar.Enabled != nil && *s.HotReloadSidecar.Enabled {
+		if s.EmbeddedConfigurationSecretRef == nil {
+			errs = append(errs, field.Required(specPath.Child("embeddedConfigurationSecretRef"), fmt.Sprintf("required when %s is true", specPath.Child("hotReloadSidecar", "enabled"))))
+		}
+		if s.HotReloadSidecar.Image == "" {
+			errs = append(errs, field.Required(specPath.Child("hotReloadSidecar", "image"), ""))
+		}
+	}
+
+	return errs
+}
+
+// isLogsVolumeFile returns whether the clean file path is located in the
+// volume shared by the gateway and the log forwarder
+func isLogsVolumeFile(file string) bool {
+	return strings.HasPrefix(file, apicast.AccessLogsMountPath+"/")
+}
+
+// isPolicyDirectoryMounted returns whether the policy directory is located in
+// a volume of the gateway container that can hold policies, i.e. the custom
+// policies or the additional volumes
+func isPolicyDirectoryMounted(directory string, s *APIcastSpec) bool {
+	mountPaths := []string{}
+	if len(s.CustomPolicies) > 0 {
+		mountPaths = append(mountPaths, apicast.CustomPoliciesMountPath)
+	}
+	for _, volumeMount := range s.VolumeMounts {
+		mountPaths = append(mountPaths, path.Clean(volumeMount.MountPath))
+	}
+	for _, mountPath := range mountPaths {
+		if directory == mountPath || strings.HasPrefix(directory, mountPath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func configurationSourceFields(specPath *field.Path) string {
+	return strings.Join([]string{
+		specPath.Child("adminPortalCredentialsRef").String(),
+		specPath.Child("embeddedConfigurationSecretRef").String(),
+		specPath.Child("embeddedConfigurationVolumeClaim").String(),
+		specPath.Child("remoteConfiguration").String(),
+	}, ", ")
+}
+
+func isDirectoryName(value string) bool {
+	return value != "" && value != "." && value != ".." && !strings.Contains(value, "/")
+}
+
+func isRelativeFilePath(value string) bool {
+	if value == "" || strings.HasPrefix(value, "/") || strings.HasSuffix(value, "/") {
+		return false
+	}
+	for _, element := range strings.Split(value, "/") {
+		if element == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// validateAnnotations checks the annotations set in the resources owned by
+// the APIcast are valid, and do not use the prefix reserved for the operator
+func validateAnnotations(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	errs := apivalidation.ValidateAnnotations(annotations, fldPath)
+	for key := range annotations {
+		if strings.HasPrefix(key, ReservedAnnotationPrefix) {
+			errs = append(errs, field.Invalid(fldPath.Key(key), key, fmt.Sprintf("the %q prefix is reserved for the operator", ReservedAnnotationPrefix)))
+		}
+	}
+	return errs
+}
+
+// validateUpstreamRetry checks the retry cases are known by APIcast, and
+// that "off" is not combined with other cases
+func validateUpstreamRetry(retry *APIcastUpstreamRetry, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	casesPath := fldPath.Child("cases")
+	if len(retry.Cases) == 0 {
+		errs = append(errs, field.Required(casesPath, ""))
+	}
+
+	for idx, retryCase := range retry.Cases {
+		if !containsString(UpstreamRetryCases, retryCase) {
+			errs = append(errs, field.NotSupported(casesPath.Index(idx), retryCase, UpstreamRetryCases))
+		}
+	}
+
+	if len(retry.Cases) > 1 && containsString(retry.Cases, "off") {
+		errs = append(errs, field.Invalid(casesPath, retry.Cases, `"off" cannot be combined with other cases`))
+	}
+
+	return errs
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCacheConfigurationJitter validates the random delay of the start of
+// the pods. It is only useful when the configuration is reloaded periodically,
+// and delaying the start more than the reload period does not spread the
+// reloads any further
+func validateCacheConfigurationJitter(s *APIcastSpec, specPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	jitterPath := specPath.Child("cacheConfigurationJitterSeconds")
+	jitterSeconds := *s.CacheConfigurationJitterSeconds
+
+	if jitterSeconds < 0 {
+		errs = append(errs, field.Invalid(jitterPath, jitterSeconds, "must be greater than or equal to 0"))
+	}
+	if s.CacheConfigurationSeconds == nil || *s.CacheConfigurationSeconds <= 0 {
+		errs = append(errs, field.Forbidden(jitterPath, fmt.Sprintf("requires %s to be greater than 0", specPath.Child("cacheConfigurationSeconds"))))
+	} else if jitterSeconds > *s.CacheConfigurationSeconds {
+		errs = append(errs, field.Invalid(jitterPath, jitterSeconds, fmt.Sprintf("must not be greater than %s", specPath.Child("cacheConfigurationSeconds"))))
+	}
+
+	return errs
+}
+
+// IsWildcardHost returns whether the host is a wildcard domain name, i.e.
+// *.apis.example.com, matching any subdomain
+func IsWildcardHost(host string) bool {
+	return strings.HasPrefix(host, "*.")
+}
+
+// validateExposedHostNames validates the domain names of the exposed hosts,
+// which can be wildcards
+func validateExposedHostNames(exposedHost *APIcastExposedHost, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	validateHost := func(host string, hostPath *field.Path) {
+		var msgs []string
+		if IsWildcardHost(host) {
+			msgs = validation.IsWildcardDNS1123Subdomain(host)
+		} else {
+			msgs = validation.IsDNS1123Subdomain(host)
+		}
+		for _, msg := range msgs {
+			errs = append(errs, field.Invalid(hostPath, host, msg))
+		}
+	}
+
+	validateHost(exposedHost.Host, fldPath.Child("host"))
+	for idx, host := range exposedHost.AdditionalHosts {
+		validateHost(host, fldPath.Child("additionalHosts").Index(idx))
+	}
+
+	return errs
+}
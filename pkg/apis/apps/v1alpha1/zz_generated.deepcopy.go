@@ -7,6 +7,7 @@ package v1alpha1
 import (
 	v1 "k8s.io/api/core/v1"
 	v1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -38,6 +39,48 @@ func (in *APIcast) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastAccessLogSidecar) DeepCopyInto(out *APIcastAccessLogSidecar) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Forwarder != nil {
+		in, out := &in.Forwarder, &out.Forwarder
+		*out = new(APIcastLogForwarder)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastAccessLogSidecar.
+func (in *APIcastAccessLogSidecar) DeepCopy() *APIcastAccessLogSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastAccessLogSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastCertManager) DeepCopyInto(out *APIcastCertManager) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastCertManager.
+func (in *APIcastCertManager) DeepCopy() *APIcastCertManager {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastCertManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIcastCondition) DeepCopyInto(out *APIcastCondition) {
 	*out = *in
@@ -55,61 +98,557 @@ func (in *APIcastCondition) DeepCopy() *APIcastCondition {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *APIcastExposedHost) DeepCopyInto(out *APIcastExposedHost) {
+func (in *APIcastConfigurationVolumeClaim) DeepCopyInto(out *APIcastConfigurationVolumeClaim) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastConfigurationVolumeClaim.
+func (in *APIcastConfigurationVolumeClaim) DeepCopy() *APIcastConfigurationVolumeClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastConfigurationVolumeClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastCustomPolicy) DeepCopyInto(out *APIcastCustomPolicy) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastCustomPolicy.
+func (in *APIcastCustomPolicy) DeepCopy() *APIcastCustomPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastCustomPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastEmptyDirVolumeSource) DeepCopyInto(out *APIcastEmptyDirVolumeSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastEmptyDirVolumeSource.
+func (in *APIcastEmptyDirVolumeSource) DeepCopy() *APIcastEmptyDirVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastEmptyDirVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastErrorLog) DeepCopyInto(out *APIcastErrorLog) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastErrorLog.
+func (in *APIcastErrorLog) DeepCopy() *APIcastErrorLog {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastErrorLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastExposedHost) DeepCopyInto(out *APIcastExposedHost) {
+	*out = *in
+	if in.AdditionalHosts != nil {
+		in, out := &in.AdditionalHosts, &out.AdditionalHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = make([]v1beta1.IngressTLS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(APIcastCertManager)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastExposedHost.
+func (in *APIcastExposedHost) DeepCopy() *APIcastExposedHost {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastExposedHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastHotReloadSpec) DeepCopyInto(out *APIcastHotReloadSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastHotReloadSpec.
+func (in *APIcastHotReloadSpec) DeepCopy() *APIcastHotReloadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastHotReloadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastInitContainer) DeepCopyInto(out *APIcastInitContainer) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastInitContainer.
+func (in *APIcastInitContainer) DeepCopy() *APIcastInitContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastInitContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastList) DeepCopyInto(out *APIcastList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]APIcast, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastList.
+func (in *APIcastList) DeepCopy() *APIcastList {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIcastList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastLogForwarder) DeepCopyInto(out *APIcastLogForwarder) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastLogForwarder.
+func (in *APIcastLogForwarder) DeepCopy() *APIcastLogForwarder {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastLogForwarder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastManagedResources) DeepCopyInto(out *APIcastManagedResources) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastManagedResources.
+func (in *APIcastManagedResources) DeepCopy() *APIcastManagedResources {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastManagedResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastNetworkPolicy) DeepCopyInto(out *APIcastNetworkPolicy) {
+	*out = *in
+	if in.ProxyFrom != nil {
+		in, out := &in.ProxyFrom, &out.ProxyFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManagementFrom != nil {
+		in, out := &in.ManagementFrom, &out.ManagementFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastNetworkPolicy.
+func (in *APIcastNetworkPolicy) DeepCopy() *APIcastNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastPort) DeepCopyInto(out *APIcastPort) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastPort.
+func (in *APIcastPort) DeepCopy() *APIcastPort {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastPorts) DeepCopyInto(out *APIcastPorts) {
+	*out = *in
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(APIcastPort)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Management != nil {
+		in, out := &in.Management, &out.Management
+		*out = new(APIcastPort)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(APIcastPort)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastPorts.
+func (in *APIcastPorts) DeepCopy() *APIcastPorts {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastPorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastPreStopHook) DeepCopyInto(out *APIcastPreStopHook) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SleepSeconds != nil {
+		in, out := &in.SleepSeconds, &out.SleepSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastPreStopHook.
+func (in *APIcastPreStopHook) DeepCopy() *APIcastPreStopHook {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastPreStopHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastProbe) DeepCopyInto(out *APIcastProbe) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessThreshold != nil {
+		in, out := &in.SuccessThreshold, &out.SuccessThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastProbe.
+func (in *APIcastProbe) DeepCopy() *APIcastProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastProxy) DeepCopyInto(out *APIcastProxy) {
+	*out = *in
+	if in.HTTPProxy != nil {
+		in, out := &in.HTTPProxy, &out.HTTPProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPSProxy != nil {
+		in, out := &in.HTTPSProxy, &out.HTTPSProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastProxy.
+func (in *APIcastProxy) DeepCopy() *APIcastProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastRemoteConfiguration) DeepCopyInto(out *APIcastRemoteConfiguration) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastRemoteConfiguration.
+func (in *APIcastRemoteConfiguration) DeepCopy() *APIcastRemoteConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastRemoteConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastReporting) DeepCopyInto(out *APIcastReporting) {
+	*out = *in
+	if in.Threads != nil {
+		in, out := &in.Threads, &out.Threads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatcherSharedMemorySizeMiB != nil {
+		in, out := &in.BatcherSharedMemorySizeMiB, &out.BatcherSharedMemorySizeMiB
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastReporting.
+func (in *APIcastReporting) DeepCopy() *APIcastReporting {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastReporting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastSecureMetrics) DeepCopyInto(out *APIcastSecureMetrics) {
 	*out = *in
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = make([]v1beta1.IngressTLS, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.TLSSecretRef != nil {
+		in, out := &in.TLSSecretRef, &out.TLSSecretRef
+		*out = new(v1.LocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BasicAuthSecretRef != nil {
+		in, out := &in.BasicAuthSecretRef, &out.BasicAuthSecretRef
+		*out = new(v1.LocalObjectReference)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastExposedHost.
-func (in *APIcastExposedHost) DeepCopy() *APIcastExposedHost {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastSecureMetrics.
+func (in *APIcastSecureMetrics) DeepCopy() *APIcastSecureMetrics {
 	if in == nil {
 		return nil
 	}
-	out := new(APIcastExposedHost)
+	out := new(APIcastSecureMetrics)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *APIcastList) DeepCopyInto(out *APIcastList) {
+func (in *APIcastServicesFilter) DeepCopyInto(out *APIcastServicesFilter) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]APIcast, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ServiceIDs != nil {
+		in, out := &in.ServiceIDs, &out.ServiceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.URLFilter != nil {
+		in, out := &in.URLFilter, &out.URLFilter
+		*out = new(string)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastList.
-func (in *APIcastList) DeepCopy() *APIcastList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastServicesFilter.
+func (in *APIcastServicesFilter) DeepCopy() *APIcastServicesFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(APIcastList)
+	out := new(APIcastServicesFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *APIcastList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIcastSpec) DeepCopyInto(out *APIcastSpec) {
 	*out = *in
@@ -118,9 +657,24 @@ func (in *APIcastSpec) DeepCopyInto(out *APIcastSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	if in.AdminPortalCredentialsRef != nil {
 		in, out := &in.AdminPortalCredentialsRef, &out.AdminPortalCredentialsRef
-		*out = new(v1.LocalObjectReference)
+		*out = new(v1.SecretReference)
 		**out = **in
 	}
 	if in.EmbeddedConfigurationSecretRef != nil {
@@ -133,6 +687,11 @@ func (in *APIcastSpec) DeepCopyInto(out *APIcastSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(string)
@@ -160,7 +719,7 @@ func (in *APIcastSpec) DeepCopyInto(out *APIcastSpec) {
 	}
 	if in.ConfigurationLoadMode != nil {
 		in, out := &in.ConfigurationLoadMode, &out.ConfigurationLoadMode
-		*out = new(string)
+		*out = new(ConfigurationLoadModeType)
 		**out = **in
 	}
 	if in.LogLevel != nil {
@@ -168,11 +727,21 @@ func (in *APIcastSpec) DeepCopyInto(out *APIcastSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OIDCLogLevel != nil {
+		in, out := &in.OIDCLogLevel, &out.OIDCLogLevel
+		*out = new(string)
+		**out = **in
+	}
 	if in.PathRoutingEnabled != nil {
 		in, out := &in.PathRoutingEnabled, &out.PathRoutingEnabled
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PathRoutingOnly != nil {
+		in, out := &in.PathRoutingOnly, &out.PathRoutingOnly
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ResponseCodesIncluded != nil {
 		in, out := &in.ResponseCodesIncluded, &out.ResponseCodesIncluded
 		*out = new(bool)
@@ -183,9 +752,14 @@ func (in *APIcastSpec) DeepCopyInto(out *APIcastSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.CacheConfigurationJitterSeconds != nil {
+		in, out := &in.CacheConfigurationJitterSeconds, &out.CacheConfigurationJitterSeconds
+		*out = new(int64)
+		**out = **in
+	}
 	if in.ManagementAPIScope != nil {
 		in, out := &in.ManagementAPIScope, &out.ManagementAPIScope
-		*out = new(string)
+		*out = new(ManagementAPIScopeType)
 		**out = **in
 	}
 	if in.OpenSSLPeerVerificationEnabled != nil {
@@ -193,6 +767,278 @@ func (in *APIcastSpec) DeepCopyInto(out *APIcastSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LazyLoadServices != nil {
+		in, out := &in.LazyLoadServices, &out.LazyLoadServices
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExtendedMetrics != nil {
+		in, out := &in.ExtendedMetrics, &out.ExtendedMetrics
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(APIcastProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPSPort != nil {
+		in, out := &in.HTTPSPort, &out.HTTPSPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HTTPSVerifyDepth != nil {
+		in, out := &in.HTTPSVerifyDepth, &out.HTTPSVerifyDepth
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SafeRollout != nil {
+		in, out := &in.SafeRollout, &out.SafeRollout
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AccessLogSidecar != nil {
+		in, out := &in.AccessLogSidecar, &out.AccessLogSidecar
+		*out = new(APIcastAccessLogSidecar)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreStopHook != nil {
+		in, out := &in.PreStopHook, &out.PreStopHook
+		*out = new(APIcastPreStopHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HotReloadSidecar != nil {
+		in, out := &in.HotReloadSidecar, &out.HotReloadSidecar
+		*out = new(APIcastHotReloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(APIcastPorts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityClassName != nil {
+		in, out := &in.PriorityClassName, &out.PriorityClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]APIcastInitContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(APIcastProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalEmbeddedConfigurationSecretRefs != nil {
+		in, out := &in.AdditionalEmbeddedConfigurationSecretRefs, &out.AdditionalEmbeddedConfigurationSecretRefs
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceNamePrefix != nil {
+		in, out := &in.ResourceNamePrefix, &out.ResourceNamePrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceNameSuffix != nil {
+		in, out := &in.ResourceNameSuffix, &out.ResourceNameSuffix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]APIcastVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServicesFilter != nil {
+		in, out := &in.ServicesFilter, &out.ServicesFilter
+		*out = new(APIcastServicesFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagementServiceEnabled != nil {
+		in, out := &in.ManagementServiceEnabled, &out.ManagementServiceEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdoptReferencedSecrets != nil {
+		in, out := &in.AdoptReferencedSecrets, &out.AdoptReferencedSecrets
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CustomPolicies != nil {
+		in, out := &in.CustomPolicies, &out.CustomPolicies
+		*out = make([]APIcastCustomPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PolicyLoadPath != nil {
+		in, out := &in.PolicyLoadPath, &out.PolicyLoadPath
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Upstream != nil {
+		in, out := &in.Upstream, &out.Upstream
+		*out = new(APIcastUpstream)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reporting != nil {
+		in, out := &in.Reporting, &out.Reporting
+		*out = new(APIcastReporting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedConfigurationVolumeClaim != nil {
+		in, out := &in.EmbeddedConfigurationVolumeClaim, &out.EmbeddedConfigurationVolumeClaim
+		*out = new(APIcastConfigurationVolumeClaim)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadType != nil {
+		in, out := &in.WorkloadType, &out.WorkloadType
+		*out = new(WorkloadType)
+		**out = **in
+	}
+	if in.ConfigurationReload != nil {
+		in, out := &in.ConfigurationReload, &out.ConfigurationReload
+		*out = new(ConfigurationReloadType)
+		**out = **in
+	}
+	if in.UpstreamTLS != nil {
+		in, out := &in.UpstreamTLS, &out.UpstreamTLS
+		*out = new(APIcastUpstreamTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackendCacheHandler != nil {
+		in, out := &in.BackendCacheHandler, &out.BackendCacheHandler
+		*out = new(BackendCacheHandlerType)
+		**out = **in
+	}
+	if in.ServiceType != nil {
+		in, out := &in.ServiceType, &out.ServiceType
+		*out = new(v1.ServiceType)
+		**out = **in
+	}
+	if in.ExternalTrafficPolicy != nil {
+		in, out := &in.ExternalTrafficPolicy, &out.ExternalTrafficPolicy
+		*out = new(v1.ServiceExternalTrafficPolicyType)
+		**out = **in
+	}
+	if in.SessionAffinity != nil {
+		in, out := &in.SessionAffinity, &out.SessionAffinity
+		*out = new(v1.ServiceAffinity)
+		**out = **in
+	}
+	if in.ErrorLog != nil {
+		in, out := &in.ErrorLog, &out.ErrorLog
+		*out = new(APIcastErrorLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagementPortExposed != nil {
+		in, out := &in.ManagementPortExposed, &out.ManagementPortExposed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DNSPolicy != nil {
+		in, out := &in.DNSPolicy, &out.DNSPolicy
+		*out = new(v1.DNSPolicy)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(APIcastProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(APIcastNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteConfiguration != nil {
+		in, out := &in.RemoteConfiguration, &out.RemoteConfiguration
+		*out = new(APIcastRemoteConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PropagateLabels != nil {
+		in, out := &in.PropagateLabels, &out.PropagateLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Standby != nil {
+		in, out := &in.Standby, &out.Standby
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UpstreamRetry != nil {
+		in, out := &in.UpstreamRetry, &out.UpstreamRetry
+		*out = new(APIcastUpstreamRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeploymentAnnotations != nil {
+		in, out := &in.DeploymentAnnotations, &out.DeploymentAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecureMetrics != nil {
+		in, out := &in.SecureMetrics, &out.SecureMetrics
+		*out = new(APIcastSecureMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManageService != nil {
+		in, out := &in.ManageService, &out.ManageService
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManageIngress != nil {
+		in, out := &in.ManageIngress, &out.ManageIngress
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -214,6 +1060,16 @@ func (in *APIcastStatus) DeepCopyInto(out *APIcastStatus) {
 		*out = make([]APIcastCondition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ManagedResources != nil {
+		in, out := &in.ManagedResources, &out.ManagedResources
+		*out = new(APIcastManagedResources)
+		**out = **in
+	}
+	if in.AdoptedSecrets != nil {
+		in, out := &in.AdoptedSecrets, &out.AdoptedSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -226,3 +1082,93 @@ func (in *APIcastStatus) DeepCopy() *APIcastStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastUpstream) DeepCopyInto(out *APIcastUpstream) {
+	*out = *in
+	if in.KeepaliveRequests != nil {
+		in, out := &in.KeepaliveRequests, &out.KeepaliveRequests
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastUpstream.
+func (in *APIcastUpstream) DeepCopy() *APIcastUpstream {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastUpstream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastUpstreamRetry) DeepCopyInto(out *APIcastUpstreamRetry) {
+	*out = *in
+	if in.Cases != nil {
+		in, out := &in.Cases, &out.Cases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastUpstreamRetry.
+func (in *APIcastUpstreamRetry) DeepCopy() *APIcastUpstreamRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastUpstreamRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastUpstreamTLS) DeepCopyInto(out *APIcastUpstreamTLS) {
+	*out = *in
+	out.ClientCertificateSecretRef = in.ClientCertificateSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastUpstreamTLS.
+func (in *APIcastUpstreamTLS) DeepCopy() *APIcastUpstreamTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastUpstreamTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIcastVolume) DeepCopyInto(out *APIcastVolume) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(APIcastEmptyDirVolumeSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIcastVolume.
+func (in *APIcastVolume) DeepCopy() *APIcastVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(APIcastVolume)
+	in.DeepCopyInto(out)
+	return out
+}
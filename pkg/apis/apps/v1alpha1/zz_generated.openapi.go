@@ -75,9 +75,31 @@ func schema_pkg_apis_apps_v1alpha1_APIcastSpec(ref common.ReferenceCallback) com
 							Format:      "int64",
 						},
 					},
+					"revisionHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of old ReplicaSets of the gateway Deployment to retain to allow rollback",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"minReadySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum number of seconds a new gateway pod has to be ready to be considered available during a rollout",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"progressDeadlineSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum number of seconds for the gateway Deployment to make progress before it is considered failed",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"adminPortalCredentialsRef": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/api/core/v1.LocalObjectReference"),
+							Description: "Secret with the admin portal endpoint. It can be in a different namespace than the APIcast resource",
+							Ref:         ref("k8s.io/api/core/v1.SecretReference"),
 						},
 					},
 					"embeddedConfigurationSecretRef": {
@@ -129,8 +151,9 @@ func schema_pkg_apis_apps_v1alpha1_APIcastSpec(ref common.ReferenceCallback) com
 					},
 					"configurationLoadMode": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Defines when the configuration is loaded: on boot, or on the first request of each service and when the cache expires",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 					"logLevel": {
@@ -139,12 +162,25 @@ func schema_pkg_apis_apps_v1alpha1_APIcastSpec(ref common.ReferenceCallback) com
 							Format: "",
 						},
 					},
+					"oidcLogLevel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Log level of the OpenID Connect module, set independently of LogLevel",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"pathRoutingEnabled": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"boolean"},
 							Format: "",
 						},
 					},
+					"pathRoutingOnly": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
 					"responseCodesIncluded": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"boolean"},
@@ -153,14 +189,23 @@ func schema_pkg_apis_apps_v1alpha1_APIcastSpec(ref common.ReferenceCallback) com
 					},
 					"cacheConfigurationSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int64",
+							Description: "Period the configuration is cached. 0 disables the cache and negative values cache it forever",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"cacheConfigurationJitterSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum random delay before starting the gateway of each pod, so the periodic reloads of the configuration of the pods are spread over time. Every new pod is delayed, so it slows down rollouts and scale ups",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
 					"managementAPIScope": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Scope of the management API: disabled, status (read only health checks), policies (read only configuration) or debug (full access)",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 					"openSSLPeerVerificationEnabled": {
@@ -169,11 +214,425 @@ func schema_pkg_apis_apps_v1alpha1_APIcastSpec(ref common.ReferenceCallback) com
 							Format: "",
 						},
 					},
+					"lazyLoadServices": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"extendedMetrics": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"proxy": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastProxy"),
+						},
+					},
+					"httpsPort": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"httpsVerifyDepth": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"safeRollout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pauses the rollout of the gateway Deployment, preserving the running pods, while the APIcast resource or its referenced secrets are invalid",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"terminationGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration in seconds the gateway pods are given to finish the in-flight requests before they are killed",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"accessLogSidecar": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastAccessLogSidecar"),
+						},
+					},
+					"preStopHook": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastPreStopHook"),
+						},
+					},
+					"hotReloadSidecar": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastHotReloadSpec"),
+						},
+					},
+					"ports": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastPorts"),
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority class of the gateway pods",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"initContainers": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastInitContainer"),
+									},
+								},
+							},
+						},
+					},
+					"readinessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastProbe"),
+						},
+					},
+					"additionalEmbeddedConfigurationSecretRefs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secrets with gateway configuration merged with the one in EmbeddedConfigurationSecretRef",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
+					"resourceNamePrefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Prefix of the names of the resources created for the APIcast. Defaults to \"apicast-\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourceNameSuffix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Suffix of the names of the resources created for the APIcast",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"automountServiceAccountToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the service account token is mounted in the APIcast pods",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"volumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional volumes of the APIcast pods",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastVolume"),
+									},
+								},
+							},
+						},
+					},
+					"volumeMounts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional volume mounts of the APIcast container",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.VolumeMount"),
+									},
+								},
+							},
+						},
+					},
+					"servicesFilter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subset of the services loaded by the gateway",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastServicesFilter"),
+						},
+					},
+					"managementServiceEnabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Expose the management port on a dedicated internal Service instead of the gateway Service",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"adoptReferencedSecrets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Set the APIcast resource as owner of the referenced secrets so they are watched. Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"customPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Custom policies mounted in the gateway policy load path",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastCustomPolicy"),
+									},
+								},
+							},
+						},
+					},
+					"policyLoadPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Directories where the gateway looks for policies, in order of precedence. Each directory has to be in the custom policies directory or in a volume mounted with volumeMounts",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"upstream": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Connection settings of the gateway with the upstream APIs",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastUpstream"),
+						},
+					},
+					"reporting": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Settings of the reporting of the traffic to the 3scale backend",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastReporting"),
+						},
+					},
+					"embeddedConfigurationVolumeClaim": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Existing PersistentVolumeClaim with the gateway configuration file. It is an alternative to EmbeddedConfigurationSecretRef for configurations exceeding the size limit of the secrets",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastConfigurationVolumeClaim"),
+						},
+					},
+					"workloadType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the workload running the gateway pods: a Deployment with Replicas pods, or a DaemonSet with one pod per node",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"configurationReload": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How the gateway pods pick up the changes of the embedded configuration secret: rolling out new pods, or reloading it when the configuration cache expires",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"upstreamTLS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Client certificate presented by the gateway to the upstream APIs requiring mutual TLS",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastUpstreamTLS"),
+						},
+					},
+					"backendCacheHandler": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Behavior of the gateway when the 3scale backend is unavailable: strict denies the requests not authorized in the cache, resilient keeps authorizing the cached applications",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the gateway Service. Defaults to ClusterIP",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"externalTrafficPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the gateway Service routes the external traffic to node-local endpoints only, preserving the client source IP. Only allowed for the NodePort and LoadBalancer Service types",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sessionAffinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Session affinity of the gateway Service",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"errorLog": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Error log file of the gateway, written to the shared logs volume instead of to the standard error",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastErrorLog"),
+						},
+					},
+					"managementPortExposed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Expose the management port in a Service. When false, the management API is only meant to be reached from within the gateway pods, i.e. by sidecars. Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"dnsPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNS policy of the gateway pods. Defaults to ClusterFirst",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dnsConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNS settings of the gateway pods, merged with the ones of the DNS policy",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+						},
+					},
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Command of the gateway container, replacing the entrypoint of the image",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"args": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Arguments of the gateway container, replacing the command of the image",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"livenessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timing of the liveness probe of the gateway container, i.e. to give time to slow-booting gateways to load a large configuration",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastProbe"),
+						},
+					},
+					"networkPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkPolicy restricting the traffic of the gateway pods, created by the operator when set",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastNetworkPolicy"),
+						},
+					},
+					"remoteConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL serving the gateway configuration, loaded instead of the one of the 3scale Porta endpoint or an embedded one",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastRemoteConfiguration"),
+						},
+					},
+					"propagateLabels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Keys of the labels of the APIcast resource copied to the gateway pods, i.e. for cost allocation",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"standby": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deploy the gateway pods without sending them traffic: the Service does not select them, i.e. to pre-warm a new version before switching the traffic to it",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"upstreamRetry": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Settings of the retries of the requests to the upstream APIs by the retry policy",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastUpstreamRetry"),
+						},
+					},
+					"deploymentAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations of the gateway Deployment or DaemonSet, i.e. for GitOps tools. They are not set in the gateway pods",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"serviceAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations of the gateway Service, i.e. the hints of the LoadBalancer of the cloud provider",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"suspend": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scale the gateway Deployment down to zero replicas, keeping Replicas to restore them when it is unset. The Service and the Ingress are kept",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"secureMetrics": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sidecar serving the metrics over TLS and/or with basic authentication",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastSecureMetrics"),
+						},
+					},
+					"manageService": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the operator creates and reconciles the gateway Service. Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"manageIngress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the operator creates and reconciles the gateway Ingress. Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastExposedHost", "k8s.io/api/core/v1.LocalObjectReference"},
+			"github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastAccessLogSidecar", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastConfigurationVolumeClaim", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastCustomPolicy", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastErrorLog", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastExposedHost", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastHotReloadSpec", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastInitContainer", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastNetworkPolicy", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastPorts", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastPreStopHook", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastProbe", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastProxy", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastRemoteConfiguration", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastReporting", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastSecureMetrics", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastServicesFilter", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastUpstream", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastUpstreamRetry", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastUpstreamTLS", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastVolume", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.SecretReference", "k8s.io/api/core/v1.VolumeMount"},
 	}
 }
 
@@ -209,10 +668,44 @@ func schema_pkg_apis_apps_v1alpha1_APIcastStatus(ref common.ReferenceCallback) c
 							Format:      "",
 						},
 					},
+					"managedResources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names of the resources managed by the operator for the APIcast",
+							Ref:         ref("github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastManagedResources"),
+						},
+					},
+					"adoptedSecrets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names of the referenced secrets adopted by the APIcast. They are released when they are no longer referenced",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"watchedSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace/name of the admin portal credentials secret of another namespace annotated with the APIcast. It is removed from the annotation when the secret is no longer referenced",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Label selector of the APIcast pods",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastCondition"},
+			"github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastCondition", "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1.APIcastManagedResources"},
 	}
 }
@@ -2,6 +2,10 @@ package apicast
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/3scale/apicast-operator/version"
 
@@ -9,8 +13,10 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -37,6 +43,13 @@ func Add(mgr manager.Manager) error {
 	return add(mgr, reconciler)
 }
 
+// AdminPortalCredentialsNamespaces are the namespaces, other than the one of
+// the APIcast resource, from which the APIcast resources can reference admin
+// portal credentials secrets. It is set from the operator flags before the
+// controller is added to the manager. By default it is empty, so the secrets
+// of other namespaces cannot be referenced
+var AdminPortalCredentialsNamespaces []string
+
 // We create an Client Reader that directly queries the API server
 // without going to the Cache provided by the Manager's Client because
 // there are some resources that do not implement Watch (like ImageStreamTag)
@@ -53,9 +66,17 @@ func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
 		return nil, err
 	}
 
-	b := NewBaseReconciler(mgr.GetClient(), apiClientReader, mgr.GetScheme(), log)
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	b := NewBaseReconciler(mgr.GetClient(), apiClientReader, mgr.GetScheme(), log, mgr.GetRecorder("apicast-controller"))
+	b = b.WithFieldManager(FieldManagerFromUserAgent(mgr.GetConfig().UserAgent))
+	b = b.WithAdminPortalCredentialsNamespaces(AdminPortalCredentialsNamespaces)
 	return &ReconcileAPIcast{
 		BaseControllerReconciler: NewBaseControllerReconciler(b),
+		discoveryClient:          discoveryClient,
 	}, nil
 }
 
@@ -82,6 +103,24 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Secrets not adopted by the APIcast resources are mapped to the APIcast
+	// resources referencing them
+	err = c.Watch(&source.Kind{Type: &v1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: referencingAPIcastRequests(mgr.GetClient()),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Admin portal credentials secrets in other namespaces cannot be owned by
+	// the APIcast resources, so they are annotated with them instead
+	err = c.Watch(&source.Kind{Type: &v1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(watchedByRequests),
+	})
+	if err != nil {
+		return err
+	}
+
 	err = c.Watch(&source.Kind{Type: &appsv1.Deployment{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &appsv1alpha1.APIcast{},
@@ -90,6 +129,14 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	err = c.Watch(&source.Kind{Type: &appsv1.DaemonSet{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &appsv1alpha1.APIcast{},
+	})
+	if err != nil {
+		return err
+	}
+
 	err = c.Watch(&source.Kind{Type: &v1.Service{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &appsv1alpha1.APIcast{},
@@ -98,6 +145,14 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	err = c.Watch(&source.Kind{Type: &v1.ServiceAccount{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &appsv1alpha1.APIcast{},
+	})
+	if err != nil {
+		return err
+	}
+
 	err = c.Watch(&source.Kind{Type: &extensions.Ingress{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &appsv1alpha1.APIcast{},
@@ -106,15 +161,101 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	err = c.Watch(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &appsv1alpha1.APIcast{},
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// referencingAPIcastRequests returns a mapper of secrets to the requests of
+// the APIcast resources of the same namespace referencing them
+func referencingAPIcastRequests(c client.Client) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		requests := []reconcile.Request{}
+
+		apicastList := &appsv1alpha1.APIcastList{}
+		err := c.List(context.TODO(), &client.ListOptions{Namespace: obj.Meta.GetNamespace()}, apicastList)
+		if err != nil {
+			log.Error(err, "Error listing APIcasts referencing secret", "Secret.Namespace", obj.Meta.GetNamespace(), "Secret.Name", obj.Meta.GetName())
+			return requests
+		}
+
+		for _, apicastCR := range apicastList.Items {
+			if isSecretReferenced(&apicastCR, obj.Meta.GetName()) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: apicastCR.Namespace, Name: apicastCR.Name},
+				})
+			}
+		}
+
+		return requests
+	}
+}
+
+// isSecretReferenced returns whether the secret of the namespace of the
+// APIcast resource is referenced by its spec
+func isSecretReferenced(apicastCR *appsv1alpha1.APIcast, secretName string) bool {
+	spec := &apicastCR.Spec
+	if ref := spec.AdminPortalCredentialsRef; ref != nil && ref.Name == secretName && (ref.Namespace == "" || ref.Namespace == apicastCR.Namespace) {
+		return true
+	}
+
+	if spec.EmbeddedConfigurationSecretRef != nil && spec.EmbeddedConfigurationSecretRef.Name == secretName {
+		return true
+	}
+
+	for _, ref := range spec.AdditionalEmbeddedConfigurationSecretRefs {
+		if ref.Name == secretName {
+			return true
+		}
+	}
+
+	if spec.UpstreamTLS != nil && spec.UpstreamTLS.ClientCertificateSecretRef.Name == secretName {
+		return true
+	}
+
+	if spec.RemoteConfiguration != nil && spec.RemoteConfiguration.CredentialsSecretRef != nil && spec.RemoteConfiguration.CredentialsSecretRef.Name == secretName {
+		return true
+	}
+
+	return false
+}
+
+// watchedByRequests returns the requests of the APIcast resources in the
+// watched-by annotation of the object
+func watchedByRequests(obj handler.MapObject) []reconcile.Request {
+	requests := []reconcile.Request{}
+	watchers := obj.Meta.GetAnnotations()[AdminPortalCredentialsWatchedByAnnotation]
+	if watchers == "" {
+		return requests
+	}
+
+	for _, watcher := range strings.Split(watchers, ",") {
+		parts := strings.SplitN(watcher, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: parts[0], Name: parts[1]},
+		})
+	}
+
+	return requests
+}
+
 // blank assignment to verify that ReconcileAPIcast implements reconcile.Reconciler
 var _ reconcile.Reconciler = &ReconcileAPIcast{}
 
 // ReconcileAPIcast reconciles a APIcast object
 type ReconcileAPIcast struct {
 	BaseControllerReconciler
+	discoveryClient discovery.DiscoveryInterface
+	backoff         requeueBackoff
 }
 
 const (
@@ -128,22 +269,54 @@ const (
 // Note:
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+// Transient errors of the API server are requeued after an exponential backoff delay instead
 func (r *ReconcileAPIcast) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	start := time.Now()
+	result, err := r.reconcile(request)
+	recordReconcileMetrics(request.NamespacedName, time.Since(start), result, err)
+	if err != nil && isTransientError(err) {
+		// The error is not returned, as the request would be requeued
+		// without waiting for the backoff delay
+		delay := r.backoff.next(request.NamespacedName, err)
+		log.Info("Transient error reconciling APIcast. Requeuing request after backoff", "Request.Namespace", request.Namespace, "Request.Name", request.Name, "Error", err.Error(), "RequeueAfter", delay.String())
+		return reconcile.Result{RequeueAfter: delay}, nil
+	}
+
+	r.backoff.reset(request.NamespacedName)
+	return result, err
+}
+
+func (r *ReconcileAPIcast) reconcile(request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling APIcast")
 
 	instance, err := r.getAPIcast(request)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.Logger().Info("APIcast not found")
+			reqLogger.Info("APIcast not found")
 			return reconcile.Result{}, nil
 		}
-		r.Logger().Error(err, "Error getting APIcast")
+		reqLogger.Error(err, "Error getting APIcast")
 		return reconcile.Result{}, err
 	}
+	reqLogger = reqLogger.WithValues("ResourceVersion", instance.ResourceVersion)
+	v1Logger := VerbosityLogger(reqLogger, instance, 1)
+
+	// The owned resources are deleted by the garbage collector, only the
+	// annotations of the secrets of other namespaces have to be removed
+	if instance.DeletionTimestamp != nil {
+		reqLogger.Info("APIcast being deleted. Finalizing it")
+		logicReconciler := NewAPIcastLogicReconciler(r.BaseReconciler, instance)
+		return reconcile.Result{}, logicReconciler.Finalize()
+	}
+
+	if isPaused(instance) {
+		reqLogger.Info("APIcast reconciliation paused. Skipping the reconciliation of the owned resources")
+		return r.reconcilePaused(instance)
+	}
 
 	if instance.ObjectMeta.Annotations == nil || instance.ObjectMeta.Annotations[APIcastOperatorVersionAnnotation] == "" {
-		r.Logger().Info("APIcast operator version not set in annotations. Setting it...")
+		v1Logger.Info("APIcast operator version not set in annotations. Setting it...")
 		if instance.ObjectMeta.Annotations == nil {
 			instance.ObjectMeta.Annotations = map[string]string{}
 		}
@@ -151,66 +324,110 @@ func (r *ReconcileAPIcast) Reconcile(request reconcile.Request) (reconcile.Resul
 		if err != nil {
 			return reconcile.Result{}, err
 		}
-		r.Logger().Info("APIcast operator version in annotations set. Requeuing request...")
+		v1Logger.Info("APIcast operator version in annotations set. Requeuing request...")
 		return reconcile.Result{Requeue: true}, err
 	}
 
 	if instance.ObjectMeta.Annotations[APIcastOperatorVersionAnnotation] != version.Version {
-		r.Logger().Info("APIcast operator version in annotations does not match expected version. Applying upgrade procedure...")
+		v1Logger.Info("APIcast operator version in annotations does not match expected version. Applying upgrade procedure...")
 		upgradeReconcileResult, err := r.upgradeAPIcast()
 		if err != nil {
-			r.Logger().Error(err, "Error upgrading APIcast")
+			reqLogger.Error(err, "Error upgrading APIcast")
 			return reconcile.Result{}, err
 		}
 		if upgradeReconcileResult.Requeue {
 			return upgradeReconcileResult, nil
 		}
-		r.Logger().Info("APIcast upgrade procedure applied")
-		r.Logger().Info("Setting APIcast operator version in annotations...")
+		v1Logger.Info("APIcast upgrade procedure applied")
+		v1Logger.Info("Setting APIcast operator version in annotations...")
 		err = r.updateAPIcastOperatorVersionInAnnotations(instance)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
-		r.Logger().Info("APIcast operator version in annotations set. Requeuing request...")
+		v1Logger.Info("APIcast operator version in annotations set. Requeuing request...")
 		return reconcile.Result{Requeue: true}, nil
 	}
 
 	logicReconciler := NewAPIcastLogicReconciler(r.BaseReconciler, instance)
+	logicReconciler.DiscoveryClient = r.discoveryClient
+
+	originalStatus := instance.Status.DeepCopy()
+	instance.Status.RemoveCondition(appsv1alpha1.PausedConditionType)
 	result, err := logicReconciler.Reconcile()
+	statusErr := r.updateReconciledStatus(instance, originalStatus)
+	if err == nil {
+		err = statusErr
+	}
 	if err != nil || result.Requeue {
-		r.Logger().Error(err, "Requeuing request...")
+		reqLogger.Error(err, "Requeuing request...")
 		return result, err
 	}
-	r.Logger().Info("APIcast logic reconciled")
+	v1Logger.Info("APIcast logic reconciled")
+	// The logic reconciler asks to be run again later to refresh the
+	// resources whose changes are not notified to the operator
+	requeueAfter := result.RequeueAfter
 
 	result, err = r.updateStatus(instance, &logicReconciler)
 	if err != nil || result.Requeue {
-		r.Logger().Error(err, "Requeuing request...")
+		reqLogger.Error(err, "Requeuing request...")
 		return result, err
 	}
-	r.Logger().Info("APIcast status reconciled")
+	v1Logger.Info("APIcast status reconciled")
 
-	r.Logger().Info("Finished current reconcile request successfully. Skipping requeue of the request")
+	if requeueAfter > 0 {
+		v1Logger.Info("Finished current reconcile request successfully. Requeuing request", "RequeueAfter", requeueAfter.String())
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+	v1Logger.Info("Finished current reconcile request successfully. Skipping requeue of the request")
 	return reconcile.Result{}, nil
 }
 
+// isPaused returns whether the reconciliation of the resources owned by the
+// APIcast resource is paused with the paused annotation
+func isPaused(instance *appsv1alpha1.APIcast) bool {
+	return instance.Annotations[PausedAnnotation] == "true"
+}
+
+// reconcilePaused only updates the status of a paused APIcast resource: the
+// Paused condition and the image of the gateway workload, if it exists
+func (r *ReconcileAPIcast) reconcilePaused(instance *appsv1alpha1.APIcast) (reconcile.Result, error) {
+	originalStatus := instance.Status.DeepCopy()
+	instance.Status.SetCondition(appsv1alpha1.APIcastCondition{
+		Type:    appsv1alpha1.PausedConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  "PausedAnnotation",
+		Message: fmt.Sprintf("The reconciliation is paused by the %s annotation", PausedAnnotation),
+	})
+	err := r.updateReconciledStatus(instance, originalStatus)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	logicReconciler := NewAPIcastLogicReconciler(r.BaseReconciler, instance)
+	result, err := r.updateStatus(instance, &logicReconciler)
+	if errors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	}
+	return result, err
+}
+
 func (r *ReconcileAPIcast) updateStatus(instance *appsv1alpha1.APIcast, reconciler *APIcastLogicReconciler) (reconcile.Result, error) {
 	apicast, err := reconciler.APIcastFromCRContents()
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	apicastDeployment := &appsv1.Deployment{}
-	err = r.Client().Get(context.TODO(), types.NamespacedName{Name: apicast.DeploymentName, Namespace: apicast.Namespace}, apicastDeployment)
-	if err != nil && !errors.IsNotFound(err) {
+	podTemplate, err := r.getWorkloadPodTemplate(apicast.DeploymentName, apicast.Namespace, apicast.DaemonSetWorkload)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	if err != nil && errors.IsNotFound(err) {
-		return reconcile.Result{}, err
+	gatewayIdx := findContainer(podTemplate.Spec.Containers, apicast.DeploymentName)
+	if gatewayIdx < 0 {
+		return reconcile.Result{Requeue: true}, nil
 	}
 
-	deployedImage := apicastDeployment.Spec.Template.Spec.Containers[0].Image
+	deployedImage := podTemplate.Spec.Containers[gatewayIdx].Image
 	if instance.Status.Image != deployedImage {
 		instance.Status.Image = deployedImage
 		err = r.Client().Status().Update(context.TODO(), instance)
@@ -222,6 +439,41 @@ func (r *ReconcileAPIcast) updateStatus(instance *appsv1alpha1.APIcast, reconcil
 	return reconcile.Result{}, nil
 }
 
+// getWorkloadPodTemplate returns the pod template of the Deployment or, when
+// daemonSet is set, of the DaemonSet running the gateway pods
+func (r *ReconcileAPIcast) getWorkloadPodTemplate(name, namespace string, daemonSet bool) (*v1.PodTemplateSpec, error) {
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	if daemonSet {
+		apicastDaemonSet := &appsv1.DaemonSet{}
+		err := r.Client().Get(context.TODO(), key, apicastDaemonSet)
+		if err != nil {
+			return nil, err
+		}
+		return &apicastDaemonSet.Spec.Template, nil
+	}
+
+	apicastDeployment := &appsv1.Deployment{}
+	err := r.Client().Get(context.TODO(), key, apicastDeployment)
+	if err != nil {
+		return nil, err
+	}
+	return &apicastDeployment.Spec.Template, nil
+}
+
+// updateReconciledStatus persists the status changes made by the logic
+// reconciler, like the conditions and the managed resources
+func (r *ReconcileAPIcast) updateReconciledStatus(instance *appsv1alpha1.APIcast, originalStatus *appsv1alpha1.APIcastStatus) error {
+	if reflect.DeepEqual(instance.Status, *originalStatus) {
+		return nil
+	}
+
+	err := r.Client().Status().Update(context.TODO(), instance)
+	if err != nil {
+		r.Logger().Error(err, "Error updating APIcast status")
+	}
+	return err
+}
+
 func (r *ReconcileAPIcast) upgradeAPIcast() (reconcile.Result, error) {
 	return reconcile.Result{}, nil
 }
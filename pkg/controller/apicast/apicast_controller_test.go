@@ -0,0 +1,327 @@
+package apicast
+
+import (
+	"context"
+	"testing"
+
+	"github.com/3scale/apicast-operator/pkg/apis"
+	appsv1alpha1 "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestReconcileNewAPIcastWithoutDefaults(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.Spec.Replicas = nil
+	cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "apicast-config"}
+	configSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: cr.Namespace},
+		Data:       map[string][]byte{"config.json": []byte("{}")},
+	}
+
+	s := scheme.Scheme
+	err := apis.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	countingClient := &updateCountingClient{Client: fake.NewFakeClientWithScheme(s, cr, configSecret)}
+	baseReconciler := NewBaseReconciler(countingClient, countingClient, s, logf.Log, &record.FakeRecorder{})
+	reconciler := &ReconcileAPIcast{BaseControllerReconciler: NewBaseControllerReconciler(baseReconciler)}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}}
+	reconciles := 0
+	for result := (reconcile.Result{Requeue: true}); result.Requeue; reconciles++ {
+		if reconciles > 10 {
+			t.Fatal("APIcast reconciliation did not finish")
+		}
+		result, err = reconciler.Reconcile(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The defaults are applied in memory, so the reconciliations are the ones
+	// setting the operator version annotation, adopting the configuration
+	// secret, creating the resources and checking nothing else changed
+	assert.Equal(t, 4, reconciles)
+	// The updates are the operator version annotation of the APIcast and the
+	// owner reference of the secret
+	assert.Equal(t, 2, countingClient.updates)
+}
+
+func TestReconcilePausedAPIcast(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "apicast-config"}
+	configSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: cr.Namespace},
+		Data:       map[string][]byte{"config.json": []byte("{}")},
+	}
+
+	s := scheme.Scheme
+	err := apis.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := fake.NewFakeClientWithScheme(s, cr, configSecret)
+	baseReconciler := NewBaseReconciler(client, client, s, logf.Log, &record.FakeRecorder{})
+	reconciler := &ReconcileAPIcast{BaseControllerReconciler: NewBaseControllerReconciler(baseReconciler)}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}}
+	reconcileAll := func() {
+		for reconciles, result := 0, (reconcile.Result{Requeue: true}); result.Requeue; reconciles++ {
+			if reconciles > 10 {
+				t.Fatal("APIcast reconciliation did not finish")
+			}
+			result, err = reconciler.Reconcile(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	setPaused := func(paused string) {
+		apicastCR := &appsv1alpha1.APIcast{}
+		if err := client.Get(context.TODO(), request.NamespacedName, apicastCR); err != nil {
+			t.Fatal(err)
+		}
+		apicastCR.Annotations[PausedAnnotation] = paused
+		if err := client.Update(context.TODO(), apicastCR); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deploymentKey := types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}
+	getDeployment := func() *appsv1.Deployment {
+		deployment := &appsv1.Deployment{}
+		if err := client.Get(context.TODO(), deploymentKey, deployment); err != nil {
+			t.Fatal(err)
+		}
+		return deployment
+	}
+
+	reconcileAll()
+	reconciledImage := getDeployment().Spec.Template.Spec.Containers[0].Image
+
+	// The manual change of the image is kept while paused, and reported in
+	// the status
+	setPaused("true")
+	deployment := getDeployment()
+	deployment.Spec.Template.Spec.Containers[0].Image = "quay.io/3scale/apicast:pinned"
+	if err := client.Update(context.TODO(), deployment); err != nil {
+		t.Fatal(err)
+	}
+	reconcileAll()
+	assert.Equal(t, "quay.io/3scale/apicast:pinned", getDeployment().Spec.Template.Spec.Containers[0].Image)
+	apicastCR := &appsv1alpha1.APIcast{}
+	if err := client.Get(context.TODO(), request.NamespacedName, apicastCR); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "quay.io/3scale/apicast:pinned", apicastCR.Status.Image)
+	assert.True(t, apicastCR.Status.IsConditionTrue(appsv1alpha1.PausedConditionType))
+
+	// The change is reverted when resumed
+	setPaused("false")
+	reconcileAll()
+	assert.Equal(t, reconciledImage, getDeployment().Spec.Template.Spec.Containers[0].Image)
+	if err := client.Get(context.TODO(), request.NamespacedName, apicastCR); err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, apicastCR.Status.GetCondition(appsv1alpha1.PausedConditionType))
+}
+
+func TestReconcileScaledDownAPIcast(t *testing.T) {
+	cr := newTestAPIcast()
+	replicas := int64(0)
+	cr.Spec.Replicas = &replicas
+	cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "apicast-config"}
+	configSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: cr.Namespace},
+		Data:       map[string][]byte{"config.json": []byte("{}")},
+	}
+
+	s := scheme.Scheme
+	err := apis.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := fake.NewFakeClientWithScheme(s, cr, configSecret)
+	baseReconciler := NewBaseReconciler(client, client, s, logf.Log, &record.FakeRecorder{})
+	reconciler := &ReconcileAPIcast{BaseControllerReconciler: NewBaseControllerReconciler(baseReconciler)}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}}
+	for reconciles, result := 0, (reconcile.Result{Requeue: true}); result.Requeue; reconciles++ {
+		if reconciles > 10 {
+			t.Fatal("APIcast reconciliation did not finish")
+		}
+		result, err = reconciler.Reconcile(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 0 replicas is not taken as unset
+	deployment := &appsv1.Deployment{}
+	deploymentKey := types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}
+	if err := client.Get(context.TODO(), deploymentKey, deployment); err != nil {
+		t.Fatal(err)
+	}
+	if assert.NotNil(t, deployment.Spec.Replicas) {
+		assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+	}
+
+	apicastCR := &appsv1alpha1.APIcast{}
+	if err := client.Get(context.TODO(), request.NamespacedName, apicastCR); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(0), *apicastCR.Spec.Replicas)
+	condition := apicastCR.Status.GetCondition(appsv1alpha1.ScaledDownConditionType)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, "ZeroReplicas", condition.Reason)
+	}
+}
+
+func TestReconcileAPIcastOwnerReferences(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.UID = types.UID("apicast-uid")
+	cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "apicast-config"}
+	cr.Spec.ExposedHost = &appsv1alpha1.APIcastExposedHost{Host: "api.example.com"}
+	configSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: cr.Namespace},
+		Data:       map[string][]byte{"config.json": []byte("{}")},
+	}
+
+	s := scheme.Scheme
+	err := apis.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := fake.NewFakeClientWithScheme(s, cr, configSecret)
+	baseReconciler := NewBaseReconciler(client, client, s, logf.Log, &record.FakeRecorder{})
+	reconciler := &ReconcileAPIcast{BaseControllerReconciler: NewBaseControllerReconciler(baseReconciler)}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}}
+	reconcileAll := func() {
+		for reconciles, result := 0, (reconcile.Result{Requeue: true}); result.Requeue; reconciles++ {
+			if reconciles > 10 {
+				t.Fatal("APIcast reconciliation did not finish")
+			}
+			result, err = reconciler.Reconcile(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	reconcileAll()
+
+	// The fake client does not run the garbage collector, so the cascade
+	// deletion is checked through the controller references it relies on
+	key := types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}
+	objects := []k8sutils.KubernetesObject{&appsv1.Deployment{}, &v1.Service{}, &extensions.Ingress{}, &v1.ServiceAccount{}}
+	for _, obj := range objects {
+		if err := client.Get(context.TODO(), key, obj); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []metav1.OwnerReference{asOwner(cr)}, obj.GetOwnerReferences(), k8sutils.ObjectInfo(obj))
+	}
+
+	// A controller reference removed by hand is restored
+	deployment := &appsv1.Deployment{}
+	if err := client.Get(context.TODO(), key, deployment); err != nil {
+		t.Fatal(err)
+	}
+	deployment.OwnerReferences = nil
+	if err := client.Update(context.TODO(), deployment); err != nil {
+		t.Fatal(err)
+	}
+	reconcileAll()
+	if err := client.Get(context.TODO(), key, deployment); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, metav1.IsControlledBy(deployment, cr))
+}
+
+func TestWatchedByRequests(t *testing.T) {
+	cases := []struct {
+		name             string
+		annotations      map[string]string
+		expectedRequests []reconcile.Request
+	}{
+		{"not annotated", nil, []reconcile.Request{}},
+		{"one APIcast", map[string]string{AdminPortalCredentialsWatchedByAnnotation: "gateways/example-apicast"}, []reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: "gateways", Name: "example-apicast"}},
+		}},
+		{"several APIcasts", map[string]string{AdminPortalCredentialsWatchedByAnnotation: "gateways/example-apicast,staging/example-apicast"}, []reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: "gateways", Name: "example-apicast"}},
+			{NamespacedName: types.NamespacedName{Namespace: "staging", Name: "example-apicast"}},
+		}},
+		{"without namespace", map[string]string{AdminPortalCredentialsWatchedByAnnotation: "example-apicast"}, []reconcile.Request{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "central-credentials", Namespace: "3scale-credentials", Annotations: tc.annotations},
+			}
+
+			requests := watchedByRequests(handler.MapObject{Meta: secret, Object: secret})
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
+
+func TestReconcileDeletedAPIcast(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	cr := newTestAPIcast()
+	cr.Spec.AdminPortalCredentialsRef = &v1.SecretReference{Name: "central-credentials", Namespace: "3scale-credentials"}
+	cr.Finalizers = []string{WatchedSecretFinalizer}
+	cr.DeletionTimestamp = &deletionTimestamp
+	sourceSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "central-credentials",
+			Namespace:   "3scale-credentials",
+			Annotations: map[string]string{AdminPortalCredentialsWatchedByAnnotation: "operator-unittest/example-apicast"},
+		},
+	}
+
+	s := scheme.Scheme
+	err := apis.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := fake.NewFakeClientWithScheme(s, cr, sourceSecret)
+	baseReconciler := NewBaseReconciler(client, client, s, logf.Log, &record.FakeRecorder{})
+	reconciler := &ReconcileAPIcast{BaseControllerReconciler: NewBaseControllerReconciler(baseReconciler)}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}}
+	_, err = reconciler.Reconcile(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The secret is released and the finalizer removed, without creating
+	// the resources of the APIcast
+	secret := &v1.Secret{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "central-credentials", Namespace: "3scale-credentials"}, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, secret.Annotations, AdminPortalCredentialsWatchedByAnnotation)
+	apicastCR := &appsv1alpha1.APIcast{}
+	err = client.Get(context.TODO(), request.NamespacedName, apicastCR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, apicastCR.Finalizers)
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}, &appsv1.Deployment{})
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -2,51 +2,120 @@ package apicast
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"net/url"
+	"path"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	apicast "github.com/3scale/apicast-operator/pkg/apicast"
 	appscommon "github.com/3scale/apicast-operator/pkg/apis/apps"
 	appsv1alpha1 "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1"
 
 	"github.com/3scale/apicast-operator/pkg/k8sutils"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// CrossNamespaceAdminPortalCredentialsResyncPeriod is the period the
+	// APIcast resources referencing admin portal credentials secrets of other
+	// namespaces are reconciled again, to copy the changes of the secret
+	CrossNamespaceAdminPortalCredentialsResyncPeriod = 5 * time.Minute
+)
+
+const (
+	// HighReplicasThreshold is the number of replicas above which a warning
+	// event is emitted, as it is likely a mistake
+	HighReplicasThreshold = 100
+)
+
 const (
 	AdmPortalSecretResverAnnotation            = "apicast.apps.3scale.net/admin-portal-secret-resource-version"
 	GatewayConfigurationSecretResverAnnotation = "apicast.apps.3scale.net/gateway-configuration-secret-resource-version"
+	UpstreamTLSSecretResverAnnotation          = "apicast.apps.3scale.net/upstream-tls-secret-resource-version"
+	RemoteConfigurationSecretResverAnnotation  = "apicast.apps.3scale.net/remote-configuration-secret-resource-version"
+	// RestartedAtAnnotation is set by users in the APIcast resource to force
+	// a rolling restart of the gateway pods. It is propagated to the pod
+	// template, so any change of its value rolls out the Deployment
+	RestartedAtAnnotation = "apicast.apps.3scale.net/restartedAt"
+	// PausedAnnotation is set to "true" by users in the APIcast resource to
+	// stop reconciling the resources it owns, i.e. to keep a manual change of
+	// the Deployment during an incident. The status is still updated
+	PausedAnnotation = "apicast.apps.3scale.net/paused"
+	// LogVerbosityAnnotation is set by users in the APIcast resource to log
+	// the messages up to the given verbosity level when reconciling it,
+	// regardless of the verbosity level of the operator
+	LogVerbosityAnnotation = "apicast.apps.3scale.net/log-verbosity"
+	// AdminPortalCredentialsWatchedByAnnotation is set in admin portal
+	// credentials secrets located in a different namespace than the APIcast
+	// resources referencing them, as they cannot be owned by them. It contains
+	// the comma separated namespace/name of the APIcast resources, which are
+	// reconciled when the secret changes
+	AdminPortalCredentialsWatchedByAnnotation = "apicast.apps.3scale.net/watched-by"
+	// WatchedSecretFinalizer is set in the APIcast resources added to the
+	// watched-by annotation of a secret, so they are removed from it when
+	// they are deleted
+	WatchedSecretFinalizer = "apicast.apps.3scale.net/watched-secret"
+	// ManagedVolumesAnnotation is set in the gateway workload with the comma
+	// separated names of the pod volumes set by the operator, so the ones
+	// removed from the APIcast resource can be told apart from the volumes
+	// injected by other controllers
+	ManagedVolumesAnnotation = "apicast.apps.3scale.net/managed-volumes"
+	// ManagedAnnotationsAnnotation is set in the gateway workload, Service and
+	// Ingress with the comma separated keys of their annotations set from the
+	// APIcast resource, so the ones removed from it can be deleted
+	ManagedAnnotationsAnnotation = "apicast.apps.3scale.net/managed-annotations"
 )
 
 type APIcastLogicReconciler struct {
 	BaseReconciler
 	APIcastCR *appsv1alpha1.APIcast
+	// DiscoveryClient checks the optional APIs are available. When it is not
+	// set they are considered not available
+	DiscoveryClient discovery.DiscoveryInterface
 }
 
 type apicastUserProvidedSecrets struct {
 	adminPortalCredentialsSecret *v1.Secret
 	gatewayEmbeddedConfigSecret  *v1.Secret
+	upstreamTLSSecret            *v1.Secret
+	remoteConfigurationSecret    *v1.Secret
 }
 
 func NewAPIcastLogicReconciler(b BaseReconciler, cr *appsv1alpha1.APIcast) APIcastLogicReconciler {
 	return APIcastLogicReconciler{
-		BaseReconciler: b,
+		BaseReconciler: b.WithValues("Name", cr.Name, "Namespace", cr.Namespace, "ResourceVersion", cr.ResourceVersion),
 		APIcastCR:      cr,
 	}
 }
 
+// V returns the logger of the given verbosity level for the APIcast
+// resource being reconciled
+func (r *APIcastLogicReconciler) V(level int) logr.InfoLogger {
+	return VerbosityLogger(r.Logger(), r.APIcastCR, level)
+}
+
 func (r *APIcastLogicReconciler) namespacedNameOnCR(obj metav1.Object) types.NamespacedName {
 	return types.NamespacedName{
 		Name:      obj.GetName(),
@@ -55,72 +124,351 @@ func (r *APIcastLogicReconciler) namespacedNameOnCR(obj metav1.Object) types.Nam
 }
 
 func (r *APIcastLogicReconciler) Reconcile() (reconcile.Result, error) {
-	r.Logger().WithValues("Name", r.APIcastCR.Name, "Namespace", r.APIcastCR.Namespace)
+	r.V(1).Info("Reconciling APIcast logic")
 
-	appliedInitialization, err := r.initialize()
+	// The defaults are set on admission by the mutating webhook. They are
+	// applied in memory too, for the APIcast resources admitted without it
+	r.APIcastCR.Spec.SetDefaults()
+
+	if validationErrs := r.APIcastCR.Validate(); len(validationErrs) > 0 {
+		return r.reconcileValidationFailure(newValidationError(validationErrs.ToAggregate()))
+	}
+
+	adminPortalCredentialsSecret, changed, err := r.reconcileAdminPortalCredentials()
 	if err != nil {
-		return reconcile.Result{}, err
+		r.setSecretResolvedCondition(err)
+		return r.reconcileValidationFailure(err)
 	}
-	if appliedInitialization {
-		// Stop the reconciliation cycle and order requeue to stop processing
-		// of reconciliation
+	if changed {
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	adminPortalCredentialsSecret, changed, err := r.reconcileAdminPortalCredentials()
+	gatewayEmbeddedConfigSecret, changed, err := r.reconcileGatewayEmbbededConfig()
 	if err != nil {
-		return reconcile.Result{}, err
+		r.setSecretResolvedCondition(err)
+		return r.reconcileValidationFailure(err)
 	}
 	if changed {
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	gatewayEmbeddedConfigSecret, changed, err := r.reconcileGatewayEmbbededConfig()
+	upstreamTLSSecret, changed, err := r.reconcileUpstreamTLSSecret()
 	if err != nil {
-		return reconcile.Result{}, err
+		r.setSecretResolvedCondition(err)
+		return r.reconcileValidationFailure(err)
+	}
+	if changed {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	remoteConfigurationSecret, changed, err := r.reconcileRemoteConfigurationSecret()
+	if err != nil {
+		r.setSecretResolvedCondition(err)
+		return r.reconcileValidationFailure(err)
 	}
 	if changed {
 		return reconcile.Result{Requeue: true}, nil
 	}
+	r.setSecretResolvedCondition(nil)
+
+	err = r.releaseUnreferencedSecrets()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
 	userProvidedSecrets := &apicastUserProvidedSecrets{
 		adminPortalCredentialsSecret: adminPortalCredentialsSecret,
 		gatewayEmbeddedConfigSecret:  gatewayEmbeddedConfigSecret,
+		upstreamTLSSecret:            upstreamTLSSecret,
+		remoteConfigurationSecret:    remoteConfigurationSecret,
 	}
 
-	// TODO this function does a little bit of creating the desiredApicast and
-	// also validation. Also validation should be done BEFORE
-	// the initialization probably
 	desiredAPIcast, err := r.internalAPIcast(userProvidedSecrets)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.reconcileValidationFailure(err)
 	}
+	r.APIcastCR.Status.RemoveCondition(appsv1alpha1.InvalidConditionType)
 
-	err = r.reconcileDeployment(*desiredAPIcast.Deployment())
-	if err != nil {
-		return reconcile.Result{}, err
+	managedResources := &appsv1alpha1.APIcastManagedResources{}
+
+	if desiredAPIcast.ManagedServiceAccount {
+		err = r.reconcileServiceAccount(*desiredAPIcast.ServiceAccount())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		managedResources.ServiceAccount = desiredAPIcast.ServiceAccountName
+	} else {
+		err = r.checkServiceAccountExists(desiredAPIcast.ServiceAccountName)
+		if err != nil {
+			return r.reconcileValidationFailure(err)
+		}
+	}
+
+	if desiredAPIcast.GatewayConfigurationClaimName != nil {
+		err = r.checkPersistentVolumeClaimExists(*desiredAPIcast.GatewayConfigurationClaimName)
+		if err != nil {
+			return r.reconcileValidationFailure(err)
+		}
+	}
+
+	// The Ingress is checked before the workload is reconciled, so a failure
+	// does not pause the rollout of the pod template just updated
+	if desiredAPIcast.ManagedIngress && r.APIcastCR.Spec.ExposedHost != nil {
+		err = r.checkWildcardCertificates(desiredAPIcast.Ingress().Spec.TLS)
+		if err != nil {
+			return r.reconcileValidationFailure(err)
+		}
+
+		if desiredAPIcast.ExposedHost.CertManagerClusterIssuer != nil {
+			err = r.checkCertificateAPIAvailable()
+			if err != nil {
+				return r.reconcileValidationFailure(err)
+			}
+		}
+	}
+
+	// The workload of the previous workload type is deleted once the new one
+	// is created, so there are gateway pods running in the meantime
+	if desiredAPIcast.DaemonSetWorkload {
+		err = r.reconcileDaemonSet(*desiredAPIcast.DaemonSet())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		managedResources.DaemonSet = desiredAPIcast.DeploymentName
+		r.APIcastCR.Status.RemoveCondition(appsv1alpha1.DegradedConditionType)
+		r.APIcastCR.Status.RemoveCondition(appsv1alpha1.ScaledDownConditionType)
+		r.APIcastCR.Status.RemoveCondition(appsv1alpha1.SuspendedConditionType)
+
+		err = r.deleteOwnedObject(desiredAPIcast.DeploymentName, &appsv1.Deployment{})
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	} else {
+		r.reconcileSuspendedCondition()
+
+		err = r.reconcileDeployment(*desiredAPIcast.Deployment())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		managedResources.Deployment = desiredAPIcast.DeploymentName
+
+		err = r.reconcileDegradedCondition(desiredAPIcast.DeploymentName)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		r.reconcileScaledDownCondition(int32(*r.APIcastCR.Spec.Replicas))
+
+		err = r.deleteOwnedObject(desiredAPIcast.DeploymentName, &appsv1.DaemonSet{})
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	r.APIcastCR.Status.RemoveCondition(appsv1alpha1.RolloutPausedConditionType)
+
+	if desiredAPIcast.ManagedService {
+		err = r.reconcileService(*desiredAPIcast.Service())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		managedResources.Service = desiredAPIcast.ServiceName
+	} else {
+		err = r.releaseOwnedObject(desiredAPIcast.ServiceName, &v1.Service{})
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if desiredAPIcast.ManagementServiceEnabled {
+		err = r.reconcileService(*desiredAPIcast.ManagementService())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		managedResources.ManagementService = desiredAPIcast.ManagementServiceName()
+	} else {
+		err = r.deleteOwnedService(desiredAPIcast.ManagementServiceName())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if desiredAPIcast.NetworkPolicyRules != nil {
+		desiredNetworkPolicy := desiredAPIcast.NetworkPolicy()
+		err = r.reconcileNetworkPolicy(*desiredNetworkPolicy)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		managedResources.NetworkPolicy = desiredNetworkPolicy.Name
+	} else {
+		err = r.deleteOwnedObject(desiredAPIcast.DeploymentName, &networkingv1.NetworkPolicy{})
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if !desiredAPIcast.ManagedIngress {
+		err = r.releaseOwnedObject(desiredAPIcast.DeploymentName, &extensions.Ingress{})
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	} else if r.APIcastCR.Spec.ExposedHost != nil {
+		desiredIngress := desiredAPIcast.Ingress()
+		err = r.reconcileIngress(*desiredIngress)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		managedResources.Ingress = desiredIngress.Name
+
+		if desiredAPIcast.ExposedHost.CertManagerClusterIssuer != nil {
+			desiredCertificate := desiredAPIcast.Certificate()
+			err = r.reconcileCertificate(desiredCertificate)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			managedResources.Certificate = desiredCertificate.GetName()
+		}
+	}
+
+	// The Certificate is only looked up when it was created, as the
+	// cert-manager API is optional
+	if previousManagedResources := r.APIcastCR.Status.ManagedResources; previousManagedResources != nil &&
+		previousManagedResources.Certificate != "" && managedResources.Certificate == "" {
+		err = r.deleteOwnedCertificate(previousManagedResources.Certificate)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	r.APIcastCR.Status.ManagedResources = managedResources
+	r.APIcastCR.Status.PodSelector = desiredAPIcast.PodSelector()
+
+	// The changes of the secrets of other namespaces are not notified when
+	// the cache of the operator is restricted to the watched namespace
+	if r.APIcastCR.Spec.AdminPortalCredentialsRef != nil && r.isCrossNamespaceAdminPortalCredentials() {
+		return reconcile.Result{RequeueAfter: CrossNamespaceAdminPortalCredentialsResyncPeriod}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileValidationFailure handles an error detected while validating the
+// APIcast resource and its referenced secrets. The error is reported in the
+// Invalid condition. When the safe rollout mode is enabled the rollout of the
+// existing Deployment is paused so the running pods are preserved until the
+// APIcast resource is valid again
+func (r *APIcastLogicReconciler) reconcileValidationFailure(validationErr error) (reconcile.Result, error) {
+	if !isValidationError(validationErr) {
+		return reconcile.Result{}, validationErr
+	}
+
+	r.APIcastCR.Status.SetCondition(appsv1alpha1.APIcastCondition{
+		Type:    appsv1alpha1.InvalidConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  "ValidationFailed",
+		Message: validationErr.Error(),
+	})
+
+	if r.APIcastCR.Spec.SafeRollout == nil || !*r.APIcastCR.Spec.SafeRollout {
+		return reconcile.Result{}, validationErr
 	}
 
-	err = r.reconcileService(*desiredAPIcast.Service())
+	existingDeployment := appsv1.Deployment{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: r.apicastFullName(), Namespace: r.APIcastCR.Namespace}, &existingDeployment)
 	if err != nil {
+		if errors.IsNotFound(err) {
+			// There are no running pods to preserve
+			return reconcile.Result{}, validationErr
+		}
 		return reconcile.Result{}, err
 	}
 
-	if r.APIcastCR.Spec.ExposedHost != nil {
-		err = r.reconcileIngress(*desiredAPIcast.Ingress())
+	if !existingDeployment.Spec.Paused {
+		existingDeployment.Spec.Paused = true
+		r.Logger().Info("Pausing rollout", "Object", k8sutils.ObjectInfo(&existingDeployment), "ResourceVersion", existingDeployment.GetResourceVersion())
+		err = r.Client().Update(context.TODO(), &existingDeployment)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
 	}
-	return reconcile.Result{}, nil
+
+	r.APIcastCR.Status.SetCondition(appsv1alpha1.APIcastCondition{
+		Type:    appsv1alpha1.RolloutPausedConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  "ValidationFailed",
+		Message: validationErr.Error(),
+	})
+
+	return reconcile.Result{}, validationErr
+}
+
+// setSecretResolvedCondition sets the SecretResolved condition from the error
+// getting the referenced secrets. Errors not caused by a missing secret or
+// key leave the condition unchanged
+func (r *APIcastLogicReconciler) setSecretResolvedCondition(err error) {
+	if err == nil {
+		r.APIcastCR.Status.SetCondition(appsv1alpha1.APIcastCondition{
+			Type:   appsv1alpha1.SecretResolvedConditionType,
+			Status: v1.ConditionTrue,
+			Reason: "SecretsResolved",
+		})
+		return
+	}
+
+	if secretErr, ok := err.(*secretResolutionError); ok {
+		r.APIcastCR.Status.SetCondition(secretErr.condition())
+	}
+}
+
+// isValidationError returns whether the error has been caused by the contents
+// of the APIcast resource or its referenced secrets instead of by a failure
+// communicating with the API server
+func isValidationError(err error) bool {
+	switch err.(type) {
+	case *validationError, *secretResolutionError:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *APIcastLogicReconciler) adminPortalCredentialsNamespace() string {
+	if r.APIcastCR.Spec.AdminPortalCredentialsRef.Namespace == "" {
+		return r.APIcastCR.Namespace
+	}
+	return r.APIcastCR.Spec.AdminPortalCredentialsRef.Namespace
+}
+
+// isCrossNamespaceAdminPortalCredentials returns whether the admin portal
+// credentials secret is in a different namespace than the APIcast resource.
+// In that case pods cannot reference it, so a copy is used instead
+func (r *APIcastLogicReconciler) isCrossNamespaceAdminPortalCredentials() bool {
+	return r.adminPortalCredentialsNamespace() != r.APIcastCR.Namespace
+}
+
+// isAdminPortalCredentialsNamespaceAllowed returns whether the admin portal
+// credentials secret can be read from its namespace. Otherwise, any APIcast
+// resource could get the operator to copy the secrets of any namespace
+func (r *APIcastLogicReconciler) isAdminPortalCredentialsNamespaceAllowed() bool {
+	if !r.isCrossNamespaceAdminPortalCredentials() {
+		return true
+	}
+	for _, namespace := range r.AdminPortalCredentialsNamespaces() {
+		if namespace == r.adminPortalCredentialsNamespace() {
+			return true
+		}
+	}
+	return false
 }
 
 func (r *APIcastLogicReconciler) getAdminPortalCredentialsSecret() (*v1.Secret, error) {
 	adminPortalSecretReference := r.APIcastCR.Spec.AdminPortalCredentialsRef
-	adminPortalNamespace := r.APIcastCR.Namespace
+	adminPortalNamespace := r.adminPortalCredentialsNamespace()
 
 	if adminPortalSecretReference.Name == "" {
-		return nil, fmt.Errorf("Field 'Name' not specified for AdminPortalCredentialsRef Secret Reference")
+		return nil, validationErrorf("Field 'Name' not specified for AdminPortalCredentialsRef Secret Reference")
+	}
+
+	if !r.isAdminPortalCredentialsNamespaceAllowed() {
+		return nil, validationErrorf("AdminPortalCredentialsRef Secret Reference namespace '%s' is not allowed. The operator only reads the secrets of the namespaces set in its --admin-portal-credentials-namespaces flag", adminPortalNamespace)
 	}
 
 	adminPortalCredentialsNamespacedName := types.NamespacedName{
@@ -128,27 +476,41 @@ func (r *APIcastLogicReconciler) getAdminPortalCredentialsSecret() (*v1.Secret,
 		Namespace: adminPortalNamespace,
 	}
 
+	// The cache of the manager might be restricted to the watched namespace
+	reader := client.Reader(r.Client())
+	if r.isCrossNamespaceAdminPortalCredentials() {
+		reader = r.APIClientReader()
+	}
+
 	adminPortalCredentialsSecret := v1.Secret{}
-	err := r.Client().Get(context.TODO(), adminPortalCredentialsNamespacedName, &adminPortalCredentialsSecret)
+	err := reader.Get(context.TODO(), adminPortalCredentialsNamespacedName, &adminPortalCredentialsSecret)
 
 	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &secretResolutionError{Field: "AdminPortalCredentialsRef", Name: adminPortalSecretReference.Name, err: err}
+		}
 		return nil, err
 	}
 
 	secretStringData := k8sutils.SecretStringDataFromData(adminPortalCredentialsSecret)
 	adminPortalURL, ok := secretStringData[apicast.AdminPortalURLAttributeName]
 	if !ok {
-		return nil, fmt.Errorf("Required key '%s' not found in secret '%s'", apicast.AdminPortalURLAttributeName, adminPortalCredentialsSecret.Name)
+		return nil, &secretResolutionError{
+			Field: "AdminPortalCredentialsRef",
+			Name:  adminPortalCredentialsSecret.Name,
+			Key:   apicast.AdminPortalURLAttributeName,
+			err:   fmt.Errorf("Required key '%s' not found in secret '%s'", apicast.AdminPortalURLAttributeName, adminPortalCredentialsSecret.Name),
+		}
 	}
 
 	parsedURL, err := url.Parse(adminPortalURL)
 	if err != nil {
-		return nil, err
+		return nil, newValidationError(err)
 	}
 
 	accessToken := parsedURL.User.Username()
 	if accessToken == "" {
-		return nil, fmt.Errorf("Access Token required in %s URL", apicast.AdminPortalURLAttributeName)
+		return nil, validationErrorf("Access Token required in %s URL", apicast.AdminPortalURLAttributeName)
 	}
 
 	return &adminPortalCredentialsSecret, err
@@ -164,13 +526,21 @@ func (r *APIcastLogicReconciler) reconcileAdminPortalCredentials() (*v1.Secret,
 		return nil, false, err
 	}
 
+	if r.isCrossNamespaceAdminPortalCredentials() {
+		return r.reconcileCrossNamespaceAdminPortalCredentials(adminPortalCredentialsSecret)
+	}
+
+	if !r.adoptReferencedSecrets() {
+		return adminPortalCredentialsSecret, false, nil
+	}
+
 	changed, err := r.ensureOwnerReference(adminPortalCredentialsSecret)
 	if err != nil {
 		return nil, changed, err
 	}
 
 	if changed {
-		r.Logger().Info(fmt.Sprintf("Updating %s", k8sutils.ObjectInfo(adminPortalCredentialsSecret)))
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(adminPortalCredentialsSecret), "ResourceVersion", adminPortalCredentialsSecret.GetResourceVersion())
 		err = r.Client().Update(context.TODO(), adminPortalCredentialsSecret)
 		if err != nil {
 			return nil, changed, err
@@ -180,351 +550,1985 @@ func (r *APIcastLogicReconciler) reconcileAdminPortalCredentials() (*v1.Secret,
 	return adminPortalCredentialsSecret, changed, nil
 }
 
-func (r *APIcastLogicReconciler) reconcileGatewayEmbbededConfig() (*v1.Secret, bool, error) {
-	if r.APIcastCR.Spec.EmbeddedConfigurationSecretRef == nil {
-		return nil, false, nil
-	}
-
-	gatewayEmbeddedConfigSecret, err := r.getGatewayEmbeddedConfigSecret()
+// reconcileCrossNamespaceAdminPortalCredentials annotates the admin portal
+// credentials secret of another namespace, as owner references cannot cross
+// namespaces, and keeps a copy of it in the namespace of the APIcast
+// resource to be referenced by the gateway pods, as pods cannot mount the
+// secrets of other namespaces. The secret is annotated even when the
+// referenced secrets are not adopted, as the annotation is the only way to
+// be notified of its changes
+func (r *APIcastLogicReconciler) reconcileCrossNamespaceAdminPortalCredentials(sourceSecret *v1.Secret) (*v1.Secret, bool, error) {
+	// The finalizer is added before the secret is annotated, so the
+	// annotation is removed even if the APIcast is deleted right after
+	if !hasFinalizer(r.APIcastCR, WatchedSecretFinalizer) {
+		err := r.updateFinalizers(append(r.APIcastCR.Finalizers, WatchedSecretFinalizer))
+		return nil, true, err
+	}
+
+	if ensureWatchedByAnnotation(sourceSecret, r.APIcastCR) {
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(sourceSecret), "ResourceVersion", sourceSecret.GetResourceVersion())
+		err := r.Client().Update(context.TODO(), sourceSecret)
+		return nil, true, err
+	}
+
+	desiredSecret := r.desiredAdminPortalCredentialsCopy(sourceSecret)
+	err := r.setOwnerReference(desiredSecret)
 	if err != nil {
 		return nil, false, err
 	}
 
-	changed, err := r.ensureOwnerReference(gatewayEmbeddedConfigSecret)
+	existingSecret := &v1.Secret{}
+	err = r.Client().Get(context.TODO(), r.namespacedName(desiredSecret), existingSecret)
 	if err != nil {
-		return nil, changed, err
+		if errors.IsNotFound(err) {
+			r.Logger().Info("Creating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(desiredSecret))
+			err = r.Client().Create(context.TODO(), desiredSecret)
+			return desiredSecret, false, err
+		}
+		return nil, false, err
 	}
 
-	if changed {
-		r.Logger().Info(fmt.Sprintf("Updating %s", k8sutils.ObjectInfo(gatewayEmbeddedConfigSecret)))
-		err = r.Client().Update(context.TODO(), gatewayEmbeddedConfigSecret)
+	if !reflect.DeepEqual(existingSecret.Data, desiredSecret.Data) {
+		existingSecret.Data = desiredSecret.Data
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(existingSecret), "ResourceVersion", existingSecret.GetResourceVersion())
+		err = r.Client().Update(context.TODO(), existingSecret)
 		if err != nil {
-			return nil, changed, err
+			return nil, false, err
 		}
 	}
 
-	return gatewayEmbeddedConfigSecret, changed, nil
+	return existingSecret, false, nil
 }
 
-func (r *APIcastLogicReconciler) getGatewayEmbeddedConfigSecret() (*v1.Secret, error) {
-	gatewayConfigSecretReference := r.APIcastCR.Spec.EmbeddedConfigurationSecretRef
-	gatewayConfigSecretNamespace := r.APIcastCR.Namespace
+// desiredAdminPortalCredentialsCopy returns the copy of an admin portal
+// credentials secret of another namespace. Only the key used by the gateway
+// is copied
+func (r *APIcastLogicReconciler) desiredAdminPortalCredentialsCopy(sourceSecret *v1.Secret) *v1.Secret {
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.apicastFullName() + "-admin-portal-credentials",
+			Namespace: r.APIcastCR.Namespace,
+		},
+		Data: map[string][]byte{
+			apicast.AdminPortalURLAttributeName: sourceSecret.Data[apicast.AdminPortalURLAttributeName],
+		},
+		Type: v1.SecretTypeOpaque,
+	}
+}
 
-	if gatewayConfigSecretReference.Name == "" {
-		return nil, fmt.Errorf("Field 'Name' not specified for EmbeddedConfigurationSecretRef Secret Reference")
+// ensureWatchedByAnnotation adds the APIcast resource to the watched-by
+// annotation of the object. It returns whether the annotation was changed
+func ensureWatchedByAnnotation(obj metav1.Object, apicastCR *appsv1alpha1.APIcast) bool {
+	watcher := fmt.Sprintf("%s/%s", apicastCR.Namespace, apicastCR.Name)
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
 	}
 
-	gatewayConfigSecretNamespacedName := types.NamespacedName{
-		Name:      gatewayConfigSecretReference.Name,
-		Namespace: gatewayConfigSecretNamespace,
+	watchers := []string{}
+	if annotations[AdminPortalCredentialsWatchedByAnnotation] != "" {
+		watchers = strings.Split(annotations[AdminPortalCredentialsWatchedByAnnotation], ",")
+	}
+	for _, existingWatcher := range watchers {
+		if existingWatcher == watcher {
+			return false
+		}
 	}
 
-	gatewayConfigSecret := v1.Secret{}
-	err := r.Client().Get(context.TODO(), gatewayConfigSecretNamespacedName, &gatewayConfigSecret)
+	watchers = append(watchers, watcher)
+	sort.Strings(watchers)
+	annotations[AdminPortalCredentialsWatchedByAnnotation] = strings.Join(watchers, ",")
+	obj.SetAnnotations(annotations)
+	return true
+}
 
-	if err != nil {
-		return nil, err
+// removeWatchedByAnnotation removes the APIcast resource from the watched-by
+// annotation of the object, and the annotation when it is the last one. It
+// returns whether the annotation was changed
+func removeWatchedByAnnotation(obj metav1.Object, apicastCR *appsv1alpha1.APIcast) bool {
+	watcher := fmt.Sprintf("%s/%s", apicastCR.Namespace, apicastCR.Name)
+
+	annotations := obj.GetAnnotations()
+	if annotations[AdminPortalCredentialsWatchedByAnnotation] == "" {
+		return false
 	}
 
-	secretStringData := k8sutils.SecretStringDataFromData(gatewayConfigSecret)
-	if _, ok := secretStringData[apicast.EmbeddedConfigurationSecretKey]; !ok {
-		return nil, fmt.Errorf("Required key '%s' not found in secret '%s'", apicast.EmbeddedConfigurationSecretKey, gatewayConfigSecret.Name)
+	watchers := []string{}
+	for _, existingWatcher := range strings.Split(annotations[AdminPortalCredentialsWatchedByAnnotation], ",") {
+		if existingWatcher != watcher {
+			watchers = append(watchers, existingWatcher)
+		}
+	}
+	if len(watchers) == len(strings.Split(annotations[AdminPortalCredentialsWatchedByAnnotation], ",")) {
+		return false
 	}
 
-	return &gatewayConfigSecret, err
+	if len(watchers) == 0 {
+		delete(annotations, AdminPortalCredentialsWatchedByAnnotation)
+	} else {
+		annotations[AdminPortalCredentialsWatchedByAnnotation] = strings.Join(watchers, ",")
+	}
+	obj.SetAnnotations(annotations)
+	return true
 }
 
-func (r APIcastLogicReconciler) ensureOwnerReference(obj metav1.Object) (bool, error) {
-	changed := false
+// watchedSecret returns the namespace/name of the secret of another namespace
+// whose watched-by annotation must contain the APIcast resource, or an empty
+// string when it references no such secret
+func (r *APIcastLogicReconciler) watchedSecret() string {
+	ref := r.APIcastCR.Spec.AdminPortalCredentialsRef
+	if ref == nil || !r.isCrossNamespaceAdminPortalCredentials() {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", ref.Namespace, ref.Name)
+}
 
-	originalSize := len(obj.GetOwnerReferences())
-	err := r.setOwnerReference(obj)
-	if err != nil {
-		return false, err
+// releaseWatchedSecret removes the APIcast resource from the watched-by
+// annotation of the secret, given by its namespace/name
+func (r *APIcastLogicReconciler) releaseWatchedSecret(watchedSecret string) error {
+	parts := strings.SplitN(watchedSecret, "/", 2)
+	if len(parts) != 2 {
+		return nil
 	}
 
-	newSize := len(obj.GetOwnerReferences())
-	if originalSize != newSize {
-		changed = true
+	secret := &v1.Secret{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Namespace: parts[0], Name: parts[1]}, secret)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
 	}
 
-	return changed, nil
+	if removeWatchedByAnnotation(secret, r.APIcastCR) {
+		r.Logger().Info("Releasing object", "Object", k8sutils.ObjectInfo(secret), "ResourceVersion", secret.GetResourceVersion())
+		return r.Client().Update(context.TODO(), secret)
+	}
+	return nil
 }
 
-func (r *APIcastLogicReconciler) UserProvidedSecretResourceVersionAnnotations(userProvidedSecrets *apicastUserProvidedSecrets) map[string]string {
-	annotations := map[string]string{}
-
-	if userProvidedSecrets.adminPortalCredentialsSecret != nil {
-		annotations[AdmPortalSecretResverAnnotation] = userProvidedSecrets.adminPortalCredentialsSecret.ResourceVersion
+// updateFinalizers sets the finalizers of the APIcast resource. The stored
+// object is updated instead of the reconciled one, which has the defaults
+// of the spec set in memory
+func (r *APIcastLogicReconciler) updateFinalizers(finalizers []string) error {
+	apicastCR := &appsv1alpha1.APIcast{}
+	err := r.Client().Get(context.TODO(), r.namespacedName(r.APIcastCR), apicastCR)
+	if err != nil {
+		return err
 	}
 
-	if userProvidedSecrets.gatewayEmbeddedConfigSecret != nil {
-		annotations[GatewayConfigurationSecretResverAnnotation] = userProvidedSecrets.gatewayEmbeddedConfigSecret.ResourceVersion
+	apicastCR.Finalizers = finalizers
+	r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(apicastCR), "ResourceVersion", apicastCR.GetResourceVersion())
+	err = r.Client().Update(context.TODO(), apicastCR)
+	if err != nil {
+		return err
 	}
 
-	return annotations
+	r.APIcastCR.Finalizers = apicastCR.Finalizers
+	r.APIcastCR.ResourceVersion = apicastCR.ResourceVersion
+	return nil
 }
 
-// APIcastFromCRContents returns an apicast.APIcast instance. This method has
-// been implemented in order to be able to obtain an APIcast instance from
-// outside the reconciler code and avoid executing the Reconcile method. Notice
-// how we get the user provided secrets (and just get, not reconcile
-// them like we do in the Reconcile method)
-func (r *APIcastLogicReconciler) APIcastFromCRContents() (*apicast.APIcast, error) {
-	var adminPortalCredentialsSecret *v1.Secret
-	var gatewayEmbeddedConfigSecret *v1.Secret
-	var err error
-
-	if r.APIcastCR.Spec.EmbeddedConfigurationSecretRef != nil {
-		gatewayEmbeddedConfigSecret, err = r.getGatewayEmbeddedConfigSecret()
-		if err != nil {
-			return nil, err
+// hasFinalizer returns whether the object has the finalizer
+func hasFinalizer(obj metav1.Object, finalizer string) bool {
+	for _, existingFinalizer := range obj.GetFinalizers() {
+		if existingFinalizer == finalizer {
+			return true
 		}
 	}
+	return false
+}
 
-	if r.APIcastCR.Spec.AdminPortalCredentialsRef != nil {
-		adminPortalCredentialsSecret, err = r.getAdminPortalCredentialsSecret()
-		if err != nil {
-			return nil, err
+// removeFinalizer returns the finalizers without the given one
+func removeFinalizer(finalizers []string, finalizer string) []string {
+	result := []string{}
+	for _, existingFinalizer := range finalizers {
+		if existingFinalizer != finalizer {
+			result = append(result, existingFinalizer)
 		}
 	}
+	return result
+}
 
-	userProvidedSecrets := &apicastUserProvidedSecrets{
-		adminPortalCredentialsSecret: adminPortalCredentialsSecret,
-		gatewayEmbeddedConfigSecret:  gatewayEmbeddedConfigSecret,
+// Finalize removes the APIcast resource being deleted from the watched-by
+// annotation of the secrets of other namespaces, and then its finalizer
+func (r *APIcastLogicReconciler) Finalize() error {
+	if !hasFinalizer(r.APIcastCR, WatchedSecretFinalizer) {
+		return nil
 	}
 
-	apicast, err := r.internalAPIcast(userProvidedSecrets)
-	if err != nil {
-		return nil, err
+	for _, watchedSecret := range []string{r.APIcastCR.Status.WatchedSecret, r.watchedSecret()} {
+		err := r.releaseWatchedSecret(watchedSecret)
+		if err != nil {
+			return err
+		}
 	}
 
-	return &apicast, nil
+	return r.updateFinalizers(removeFinalizer(r.APIcastCR.Finalizers, WatchedSecretFinalizer))
 }
 
-func (r *APIcastLogicReconciler) internalAPIcast(userProvidedSecrets *apicastUserProvidedSecrets) (apicast.APIcast, error) {
-	var err error
+func (r *APIcastLogicReconciler) reconcileGatewayEmbbededConfig() (*v1.Secret, bool, error) {
+	if r.APIcastCR.Spec.EmbeddedConfigurationSecretRef == nil {
+		return nil, false, nil
+	}
 
-	apicastFullName := "apicast-" + r.APIcastCR.Name
+	gatewayEmbeddedConfigSecrets, err := r.getGatewayEmbeddedConfigSecrets()
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed := false
+	for _, gatewayEmbeddedConfigSecret := range gatewayEmbeddedConfigSecrets {
+		if !r.adoptReferencedSecrets() {
+			break
+		}
+		secretChanged, err := r.ensureOwnerReference(gatewayEmbeddedConfigSecret)
+		if err != nil {
+			return nil, changed, err
+		}
+
+		if secretChanged {
+			changed = true
+			r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(gatewayEmbeddedConfigSecret), "ResourceVersion", gatewayEmbeddedConfigSecret.GetResourceVersion())
+			err = r.Client().Update(context.TODO(), gatewayEmbeddedConfigSecret)
+			if err != nil {
+				return nil, changed, err
+			}
+		}
+	}
+
+	if changed || len(gatewayEmbeddedConfigSecrets) == 1 {
+		return gatewayEmbeddedConfigSecrets[0], changed, nil
+	}
+
+	mergedSecret, err := r.reconcileMergedGatewayEmbeddedConfig(gatewayEmbeddedConfigSecrets)
+	return mergedSecret, changed, err
+}
+
+// getGatewayEmbeddedConfigSecrets returns the secret referenced in
+// EmbeddedConfigurationSecretRef followed by the ones referenced in
+// AdditionalEmbeddedConfigurationSecretRefs
+func (r *APIcastLogicReconciler) getGatewayEmbeddedConfigSecrets() ([]*v1.Secret, error) {
+	gatewayEmbeddedConfigSecret, err := r.getGatewayEmbeddedConfigSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := []*v1.Secret{gatewayEmbeddedConfigSecret}
+	for idx := range r.APIcastCR.Spec.AdditionalEmbeddedConfigurationSecretRefs {
+		secret, err := r.getEmbeddedConfigSecret(&r.APIcastCR.Spec.AdditionalEmbeddedConfigurationSecretRefs[idx], "AdditionalEmbeddedConfigurationSecretRefs")
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+// desiredMergedGatewayEmbeddedConfigSecret returns the secret with the merge
+// of the gateway configuration of the given secrets
+func (r *APIcastLogicReconciler) desiredMergedGatewayEmbeddedConfigSecret(secrets []*v1.Secret) (*v1.Secret, error) {
+	configurations := [][]byte{}
+	for _, secret := range secrets {
+		configurations = append(configurations, secret.Data[apicast.EmbeddedConfigurationSecretKey])
+	}
+
+	mergedConfiguration, err := apicast.MergeEmbeddedConfigurations(configurations)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.apicastFullName() + "-embedded-configuration",
+			Namespace: r.APIcastCR.Namespace,
+		},
+		Data: map[string][]byte{
+			apicast.EmbeddedConfigurationSecretKey: mergedConfiguration,
+		},
+		Type: v1.SecretTypeOpaque,
+	}, nil
+}
+
+func (r *APIcastLogicReconciler) reconcileMergedGatewayEmbeddedConfig(secrets []*v1.Secret) (*v1.Secret, error) {
+	desiredSecret, err := r.desiredMergedGatewayEmbeddedConfigSecret(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setOwnerReference(desiredSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	existingSecret := &v1.Secret{}
+	err = r.Client().Get(context.TODO(), r.namespacedName(desiredSecret), existingSecret)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.Logger().Info("Creating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(desiredSecret))
+			err = r.Client().Create(context.TODO(), desiredSecret)
+			return desiredSecret, err
+		}
+		return nil, err
+	}
+
+	if !reflect.DeepEqual(existingSecret.Data, desiredSecret.Data) {
+		existingSecret.Data = desiredSecret.Data
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(existingSecret), "ResourceVersion", existingSecret.GetResourceVersion())
+		err = r.Client().Update(context.TODO(), existingSecret)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return existingSecret, nil
+}
+
+func (r *APIcastLogicReconciler) getGatewayEmbeddedConfigSecret() (*v1.Secret, error) {
+	return r.getEmbeddedConfigSecret(r.APIcastCR.Spec.EmbeddedConfigurationSecretRef, "EmbeddedConfigurationSecretRef")
+}
+
+func (r *APIcastLogicReconciler) getEmbeddedConfigSecret(gatewayConfigSecretReference *v1.LocalObjectReference, fieldName string) (*v1.Secret, error) {
+	gatewayConfigSecretNamespace := r.APIcastCR.Namespace
+
+	if gatewayConfigSecretReference.Name == "" {
+		return nil, validationErrorf("Field 'Name' not specified for %s Secret Reference", fieldName)
+	}
+
+	gatewayConfigSecretNamespacedName := types.NamespacedName{
+		Name:      gatewayConfigSecretReference.Name,
+		Namespace: gatewayConfigSecretNamespace,
+	}
+
+	gatewayConfigSecret := v1.Secret{}
+	err := r.Client().Get(context.TODO(), gatewayConfigSecretNamespacedName, &gatewayConfigSecret)
+
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &secretResolutionError{Field: fieldName, Name: gatewayConfigSecretReference.Name, err: err}
+		}
+		return nil, err
+	}
+
+	secretStringData := k8sutils.SecretStringDataFromData(gatewayConfigSecret)
+	configuration, ok := secretStringData[apicast.EmbeddedConfigurationSecretKey]
+	if !ok {
+		return nil, &secretResolutionError{
+			Field: fieldName,
+			Name:  gatewayConfigSecret.Name,
+			Key:   apicast.EmbeddedConfigurationSecretKey,
+			err:   fmt.Errorf("Required key '%s' not found in secret '%s'", apicast.EmbeddedConfigurationSecretKey, gatewayConfigSecret.Name),
+		}
+	}
+
+	err = apicast.ValidateEmbeddedConfiguration([]byte(configuration))
+	if err != nil {
+		return nil, validationErrorf("Invalid JSON in key '%s' of secret '%s': %s", apicast.EmbeddedConfigurationSecretKey, gatewayConfigSecret.Name, err)
+	}
+
+	return &gatewayConfigSecret, err
+}
+
+func (r *APIcastLogicReconciler) getUpstreamTLSSecret() (*v1.Secret, error) {
+	upstreamTLSSecretReference := r.APIcastCR.Spec.UpstreamTLS.ClientCertificateSecretRef
+
+	if upstreamTLSSecretReference.Name == "" {
+		return nil, validationErrorf("Field 'Name' not specified for UpstreamTLS.ClientCertificateSecretRef Secret Reference")
+	}
+
+	upstreamTLSSecretNamespacedName := types.NamespacedName{
+		Name:      upstreamTLSSecretReference.Name,
+		Namespace: r.APIcastCR.Namespace,
+	}
+
+	upstreamTLSSecret := v1.Secret{}
+	err := r.Client().Get(context.TODO(), upstreamTLSSecretNamespacedName, &upstreamTLSSecret)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &secretResolutionError{Field: "UpstreamTLS.ClientCertificateSecretRef", Name: upstreamTLSSecretReference.Name, err: err}
+		}
+		return nil, err
+	}
+
+	for _, key := range []string{v1.TLSCertKey, v1.TLSPrivateKeyKey} {
+		if _, ok := upstreamTLSSecret.Data[key]; !ok {
+			return nil, &secretResolutionError{
+				Field: "UpstreamTLS.ClientCertificateSecretRef",
+				Name:  upstreamTLSSecret.Name,
+				Key:   key,
+				err:   fmt.Errorf("Required key '%s' not found in secret '%s'", key, upstreamTLSSecret.Name),
+			}
+		}
+	}
+
+	return &upstreamTLSSecret, nil
+}
+
+// reconcileUpstreamTLSSecret returns the secret with the client certificate
+// presented to the upstream APIs, adopting it when the referenced secrets
+// are owned by the APIcast resource
+func (r *APIcastLogicReconciler) reconcileUpstreamTLSSecret() (*v1.Secret, bool, error) {
+	if r.APIcastCR.Spec.UpstreamTLS == nil {
+		return nil, false, nil
+	}
+
+	upstreamTLSSecret, err := r.getUpstreamTLSSecret()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !r.adoptReferencedSecrets() {
+		return upstreamTLSSecret, false, nil
+	}
+
+	changed, err := r.ensureOwnerReference(upstreamTLSSecret)
+	if err != nil {
+		return nil, changed, err
+	}
+
+	if changed {
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(upstreamTLSSecret), "ResourceVersion", upstreamTLSSecret.GetResourceVersion())
+		err = r.Client().Update(context.TODO(), upstreamTLSSecret)
+		if err != nil {
+			return nil, changed, err
+		}
+	}
+
+	return upstreamTLSSecret, changed, nil
+}
+
+func (r *APIcastLogicReconciler) getRemoteConfigurationSecret() (*v1.Secret, error) {
+	remoteConfigurationSecretReference := r.APIcastCR.Spec.RemoteConfiguration.CredentialsSecretRef
+
+	if remoteConfigurationSecretReference.Name == "" {
+		return nil, validationErrorf("Field 'Name' not specified for RemoteConfiguration.CredentialsSecretRef Secret Reference")
+	}
+
+	remoteConfigurationSecretNamespacedName := types.NamespacedName{
+		Name:      remoteConfigurationSecretReference.Name,
+		Namespace: r.APIcastCR.Namespace,
+	}
+
+	remoteConfigurationSecret := v1.Secret{}
+	err := r.Client().Get(context.TODO(), remoteConfigurationSecretNamespacedName, &remoteConfigurationSecret)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &secretResolutionError{Field: "RemoteConfiguration.CredentialsSecretRef", Name: remoteConfigurationSecretReference.Name, err: err}
+		}
+		return nil, err
+	}
+
+	for _, key := range []string{v1.BasicAuthUsernameKey, v1.BasicAuthPasswordKey} {
+		if _, ok := remoteConfigurationSecret.Data[key]; !ok {
+			return nil, &secretResolutionError{
+				Field: "RemoteConfiguration.CredentialsSecretRef",
+				Name:  remoteConfigurationSecret.Name,
+				Key:   key,
+				err:   fmt.Errorf("Required key '%s' not found in secret '%s'", key, remoteConfigurationSecret.Name),
+			}
+		}
+	}
+
+	// The credentials are added to the URL by Kubernetes as they are, so the
+	// characters ending or splitting them have to be URL-encoded
+	for _, key := range []string{v1.BasicAuthUsernameKey, v1.BasicAuthPasswordKey} {
+		if !isURLEncodedUserinfo(string(remoteConfigurationSecret.Data[key])) {
+			return nil, validationErrorf("Key '%s' of secret '%s' must be URL-encoded: '@', ':', '/', '?', '#', spaces and '%%' not followed by two hexadecimal digits are not allowed", key, remoteConfigurationSecret.Name)
+		}
+	}
+
+	return &remoteConfigurationSecret, nil
+}
+
+// urlUserinfoDelimiters are the characters ending or splitting the
+// credentials of a URL
+const urlUserinfoDelimiters = "@:/?# "
+
+// isURLEncodedUserinfo returns whether the value can be set in the user or
+// the password of a URL without escaping it
+func isURLEncodedUserinfo(value string) bool {
+	if strings.ContainsAny(value, urlUserinfoDelimiters) {
+		return false
+	}
+	_, err := url.PathUnescape(value)
+	return err == nil
+}
+
+// reconcileRemoteConfigurationSecret returns the secret with the credentials
+// of the remote configuration URL, adopting it when the referenced secrets
+// are owned by the APIcast resource
+func (r *APIcastLogicReconciler) reconcileRemoteConfigurationSecret() (*v1.Secret, bool, error) {
+	if r.APIcastCR.Spec.RemoteConfiguration == nil || r.APIcastCR.Spec.RemoteConfiguration.CredentialsSecretRef == nil {
+		return nil, false, nil
+	}
+
+	remoteConfigurationSecret, err := r.getRemoteConfigurationSecret()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !r.adoptReferencedSecrets() {
+		return remoteConfigurationSecret, false, nil
+	}
+
+	changed, err := r.ensureOwnerReference(remoteConfigurationSecret)
+	if err != nil {
+		return nil, changed, err
+	}
+
+	if changed {
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(remoteConfigurationSecret), "ResourceVersion", remoteConfigurationSecret.GetResourceVersion())
+		err = r.Client().Update(context.TODO(), remoteConfigurationSecret)
+		if err != nil {
+			return nil, changed, err
+		}
+	}
+
+	return remoteConfigurationSecret, changed, nil
+}
+
+// adoptedSecretNames returns the names of the secrets referenced by the
+// APIcast resource in its namespace, that are owned by it
+func (r *APIcastLogicReconciler) adoptedSecretNames() []string {
+	names := []string{}
+	if !r.adoptReferencedSecrets() {
+		return names
+	}
+
+	spec := &r.APIcastCR.Spec
+	if ref := spec.AdminPortalCredentialsRef; ref != nil && !r.isCrossNamespaceAdminPortalCredentials() {
+		names = append(names, ref.Name)
+	}
+	if spec.EmbeddedConfigurationSecretRef != nil {
+		names = append(names, spec.EmbeddedConfigurationSecretRef.Name)
+	}
+	for _, ref := range spec.AdditionalEmbeddedConfigurationSecretRefs {
+		names = append(names, ref.Name)
+	}
+	if spec.UpstreamTLS != nil {
+		names = append(names, spec.UpstreamTLS.ClientCertificateSecretRef.Name)
+	}
+	if spec.RemoteConfiguration != nil && spec.RemoteConfiguration.CredentialsSecretRef != nil {
+		names = append(names, spec.RemoteConfiguration.CredentialsSecretRef.Name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// releaseUnreferencedSecrets removes the owner reference of the APIcast
+// resource from the secrets adopted in a previous reconciliation that are
+// no longer referenced, i.e. when a secret reference points to another
+// secret, so they are not deleted with the APIcast resource
+func (r *APIcastLogicReconciler) releaseUnreferencedSecrets() error {
+	adoptedSecrets := r.adoptedSecretNames()
+	for _, name := range r.APIcastCR.Status.AdoptedSecrets {
+		if idx := sort.SearchStrings(adoptedSecrets, name); idx < len(adoptedSecrets) && adoptedSecrets[idx] == name {
+			continue
+		}
+
+		secret := &v1.Secret{}
+		err := r.Client().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.APIcastCR.Namespace}, secret)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if removeOwnerReference(secret, r.APIcastCR.UID) {
+			r.Logger().Info("Releasing object", "Object", k8sutils.ObjectInfo(secret), "ResourceVersion", secret.GetResourceVersion())
+			err = r.Client().Update(context.TODO(), secret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	r.APIcastCR.Status.AdoptedSecrets = adoptedSecrets
+
+	// The secrets of other namespaces are not owned, but annotated
+	watchedSecret := r.watchedSecret()
+	if previous := r.APIcastCR.Status.WatchedSecret; previous != "" && previous != watchedSecret {
+		err := r.releaseWatchedSecret(previous)
+		if err != nil {
+			return err
+		}
+	}
+	r.APIcastCR.Status.WatchedSecret = watchedSecret
+
+	if watchedSecret == "" && hasFinalizer(r.APIcastCR, WatchedSecretFinalizer) {
+		return r.updateFinalizers(removeFinalizer(r.APIcastCR.Finalizers, WatchedSecretFinalizer))
+	}
+	return nil
+}
+
+// reconcileControllerReference restores the controller reference of the
+// desired object in the existing one, i.e. when it was removed by hand, so it
+// is deleted with the APIcast resource. Objects controlled by another owner
+// are left unchanged. It returns whether the object was updated
+func reconcileControllerReference(existing, desired metav1.Object) bool {
+	desiredOwner := metav1.GetControllerOf(desired)
+	if desiredOwner == nil || metav1.GetControllerOf(existing) != nil {
+		return false
+	}
+
+	// A non controller reference of the same owner is replaced
+	removeOwnerReference(existing, desiredOwner.UID)
+	existing.SetOwnerReferences(append(existing.GetOwnerReferences(), *desiredOwner))
+	return true
+}
+
+// removeOwnerReference removes the owner reference of the given owner from
+// the object, and returns whether it was found
+func removeOwnerReference(obj metav1.Object, owner types.UID) bool {
+	ownerReferences := []metav1.OwnerReference{}
+	for _, ownerReference := range obj.GetOwnerReferences() {
+		if ownerReference.UID != owner {
+			ownerReferences = append(ownerReferences, ownerReference)
+		}
+	}
+
+	if len(ownerReferences) == len(obj.GetOwnerReferences()) {
+		return false
+	}
+
+	obj.SetOwnerReferences(ownerReferences)
+	return true
+}
+
+// adoptReferencedSecrets returns whether the secrets referenced by the
+// APIcast resource are updated to be owned by it
+func (r *APIcastLogicReconciler) adoptReferencedSecrets() bool {
+	return r.APIcastCR.Spec.AdoptReferencedSecrets == nil || *r.APIcastCR.Spec.AdoptReferencedSecrets
+}
+
+func (r APIcastLogicReconciler) ensureOwnerReference(obj metav1.Object) (bool, error) {
+	changed := false
+
+	originalSize := len(obj.GetOwnerReferences())
+	err := r.setOwnerReference(obj)
+	if err != nil {
+		return false, err
+	}
+
+	newSize := len(obj.GetOwnerReferences())
+	if originalSize != newSize {
+		changed = true
+	}
+
+	return changed, nil
+}
+
+func (r *APIcastLogicReconciler) UserProvidedSecretResourceVersionAnnotations(userProvidedSecrets *apicastUserProvidedSecrets) map[string]string {
+	annotations := map[string]string{}
+
+	if userProvidedSecrets.adminPortalCredentialsSecret != nil {
+		annotations[AdmPortalSecretResverAnnotation] = userProvidedSecrets.adminPortalCredentialsSecret.ResourceVersion
+	}
+
+	if userProvidedSecrets.gatewayEmbeddedConfigSecret != nil {
+		annotations[GatewayConfigurationSecretResverAnnotation] = userProvidedSecrets.gatewayEmbeddedConfigSecret.ResourceVersion
+	}
+
+	if userProvidedSecrets.upstreamTLSSecret != nil {
+		annotations[UpstreamTLSSecretResverAnnotation] = userProvidedSecrets.upstreamTLSSecret.ResourceVersion
+	}
+
+	if userProvidedSecrets.remoteConfigurationSecret != nil {
+		annotations[RemoteConfigurationSecretResverAnnotation] = userProvidedSecrets.remoteConfigurationSecret.ResourceVersion
+	}
+
+	return annotations
+}
+
+// APIcastFromCRContents returns an apicast.APIcast instance. This method has
+// been implemented in order to be able to obtain an APIcast instance from
+// outside the reconciler code and avoid executing the Reconcile method. Notice
+// how we get the user provided secrets (and just get, not reconcile
+// them like we do in the Reconcile method)
+func (r *APIcastLogicReconciler) APIcastFromCRContents() (*apicast.APIcast, error) {
+	var adminPortalCredentialsSecret *v1.Secret
+	var gatewayEmbeddedConfigSecret *v1.Secret
+	var upstreamTLSSecret *v1.Secret
+	var remoteConfigurationSecret *v1.Secret
+	var err error
+
+	if validationErrs := r.APIcastCR.Validate(); len(validationErrs) > 0 {
+		return nil, validationErrs.ToAggregate()
+	}
+
+	if r.APIcastCR.Spec.EmbeddedConfigurationSecretRef != nil {
+		gatewayEmbeddedConfigSecrets, err := r.getGatewayEmbeddedConfigSecrets()
+		if err != nil {
+			return nil, err
+		}
+		gatewayEmbeddedConfigSecret = gatewayEmbeddedConfigSecrets[0]
+		if len(gatewayEmbeddedConfigSecrets) > 1 {
+			gatewayEmbeddedConfigSecret, err = r.desiredMergedGatewayEmbeddedConfigSecret(gatewayEmbeddedConfigSecrets)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if r.APIcastCR.Spec.AdminPortalCredentialsRef != nil {
+		adminPortalCredentialsSecret, err = r.getAdminPortalCredentialsSecret()
+		if err != nil {
+			return nil, err
+		}
+		if r.isCrossNamespaceAdminPortalCredentials() {
+			adminPortalCredentialsSecret = r.desiredAdminPortalCredentialsCopy(adminPortalCredentialsSecret)
+		}
+	}
+
+	if r.APIcastCR.Spec.UpstreamTLS != nil {
+		upstreamTLSSecret, err = r.getUpstreamTLSSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.APIcastCR.Spec.RemoteConfiguration != nil && r.APIcastCR.Spec.RemoteConfiguration.CredentialsSecretRef != nil {
+		remoteConfigurationSecret, err = r.getRemoteConfigurationSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	userProvidedSecrets := &apicastUserProvidedSecrets{
+		adminPortalCredentialsSecret: adminPortalCredentialsSecret,
+		gatewayEmbeddedConfigSecret:  gatewayEmbeddedConfigSecret,
+		upstreamTLSSecret:            upstreamTLSSecret,
+		remoteConfigurationSecret:    remoteConfigurationSecret,
+	}
+
+	apicast, err := r.internalAPIcast(userProvidedSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apicast, nil
+}
+
+func (r *APIcastLogicReconciler) internalAPIcast(userProvidedSecrets *apicastUserProvidedSecrets) (apicast.APIcast, error) {
+	var err error
+
+	apicastFullName := r.apicastFullName()
 	apicastExposedHost := apicast.ExposedHost{}
 	if r.APIcastCR.Spec.ExposedHost != nil {
 		apicastExposedHost.Host = r.APIcastCR.Spec.ExposedHost.Host
+		apicastExposedHost.AdditionalHosts = r.APIcastCR.Spec.ExposedHost.AdditionalHosts
+		apicastExposedHost.Path = r.APIcastCR.Spec.ExposedHost.Path
+		apicastExposedHost.Annotations = r.APIcastCR.Spec.ExposedHost.Annotations
 		apicastExposedHost.TLS = r.APIcastCR.Spec.ExposedHost.TLS
+		if r.APIcastCR.Spec.ExposedHost.CertManager != nil {
+			apicastExposedHost.CertManagerClusterIssuer = &r.APIcastCR.Spec.ExposedHost.CertManager.ClusterIssuer
+		}
+	}
+	apicastOwnerRef := asOwner(r.APIcastCR)
+
+	var deploymentEnvironment *string
+	if r.APIcastCR.Spec.DeploymentEnvironment != nil {
+		res := string(*r.APIcastCR.Spec.DeploymentEnvironment)
+		deploymentEnvironment = &res
+	}
+
+	deploymentAnnotations := r.UserProvidedSecretResourceVersionAnnotations(userProvidedSecrets)
+	if restartedAt, ok := r.APIcastCR.Annotations[RestartedAtAnnotation]; ok {
+		deploymentAnnotations[RestartedAtAnnotation] = restartedAt
+	}
+
+	var adminPortalSecretName *string
+	if userProvidedSecrets.adminPortalCredentialsSecret != nil {
+		tmpAdminPortalSecretName := userProvidedSecrets.adminPortalCredentialsSecret.Name
+		adminPortalSecretName = &tmpAdminPortalSecretName
+	}
+
+	var gatewayConfigurationSecretName *string
+	if userProvidedSecrets.gatewayEmbeddedConfigSecret != nil {
+		tmpGatewayConfigurationSecretName := userProvidedSecrets.gatewayEmbeddedConfigSecret.Name
+		gatewayConfigurationSecretName = &tmpGatewayConfigurationSecretName
+	}
+
+	image := apicast.GetDefaultImageVersion()
+	if deploymentEnvironment != nil {
+		image = apicast.GetDefaultImageVersionForEnvironment(*deploymentEnvironment)
+	}
+	if r.APIcastCR.Spec.Image != nil {
+		image = *r.APIcastCR.Spec.Image
+	}
+
+	serviceAccount := apicastFullName
+	if r.APIcastCR.Spec.ServiceAccount != nil {
+		serviceAccount = *r.APIcastCR.Spec.ServiceAccount
+	}
+
+	daemonSetWorkload := r.APIcastCR.Spec.WorkloadType != nil && *r.APIcastCR.Spec.WorkloadType == appsv1alpha1.WorkloadTypeDaemonSet
+
+	replicas := *r.APIcastCR.Spec.Replicas
+
+	// The replicas are kept in the spec to restore them when resumed
+	if r.isSuspended() {
+		replicas = 0
+	}
+
+	apicastResult := apicast.APIcast{
+		DeploymentName:                   apicastFullName,
+		ServiceName:                      apicastFullName,
+		Replicas:                         int32(replicas),
+		RevisionHistoryLimit:             r.APIcastCR.Spec.RevisionHistoryLimit,
+		ProgressDeadlineSeconds:          r.APIcastCR.Spec.ProgressDeadlineSeconds,
+		DaemonSetWorkload:                daemonSetWorkload,
+		AppLabel:                         "apicast",
+		AdditionalAnnotations:            deploymentAnnotations,
+		DeploymentAnnotations:            r.APIcastCR.Spec.DeploymentAnnotations,
+		ServiceAnnotations:               r.APIcastCR.Spec.ServiceAnnotations,
+		PodLabels:                        r.propagatedLabels(),
+		Standby:                          r.APIcastCR.Spec.Standby != nil && *r.APIcastCR.Spec.Standby,
+		ServiceAccountName:               serviceAccount,
+		AutomountServiceAccountToken:     r.APIcastCR.Spec.AutomountServiceAccountToken,
+		ManagedServiceAccount:            r.APIcastCR.Spec.ServiceAccount == nil,
+		ManagedService:                   r.manageService(),
+		ManagedIngress:                   r.manageIngress(),
+		Image:                            image,
+		Command:                          r.APIcastCR.Spec.Command,
+		Args:                             r.APIcastCR.Spec.Args,
+		ExposedHost:                      apicastExposedHost,
+		Namespace:                        r.APIcastCR.Namespace,
+		OwnerReference:                   &apicastOwnerRef,
+		AdminPortalCredentialsSecretName: adminPortalSecretName,
+		DeploymentEnvironment:            deploymentEnvironment,
+		DNSResolverAddress:               r.APIcastCR.Spec.DNSResolverAddress,
+		EnabledServices:                  r.APIcastCR.Spec.EnabledServices,
+		LogLevel:                         r.APIcastCR.Spec.LogLevel,
+		OIDCLogLevel:                     r.APIcastCR.Spec.OIDCLogLevel,
+		PathRoutingEnabled:               r.APIcastCR.Spec.PathRoutingEnabled,
+		PathRoutingOnly:                  r.APIcastCR.Spec.PathRoutingOnly,
+		ResponseCodesIncluded:            r.APIcastCR.Spec.ResponseCodesIncluded,
+		CacheConfigurationSeconds:        r.APIcastCR.Spec.CacheConfigurationSeconds,
+		CacheConfigurationJitterSeconds:  r.APIcastCR.Spec.CacheConfigurationJitterSeconds,
+		OpenSSLPeerVerificationEnabled:   r.APIcastCR.Spec.OpenSSLPeerVerificationEnabled,
+		LazyLoadServices:                 r.APIcastCR.Spec.LazyLoadServices,
+		ExtendedMetrics:                  r.APIcastCR.Spec.ExtendedMetrics,
+		ManagementServiceEnabled:         r.APIcastCR.Spec.ManagementServiceEnabled != nil && *r.APIcastCR.Spec.ManagementServiceEnabled,
+		ManagementPortHidden:             r.APIcastCR.Spec.ManagementPortExposed != nil && !*r.APIcastCR.Spec.ManagementPortExposed,
+		ServiceType:                      r.APIcastCR.Spec.ServiceType,
+		ServiceExternalTrafficPolicy:     r.APIcastCR.Spec.ExternalTrafficPolicy,
+		ServiceSessionAffinity:           r.APIcastCR.Spec.SessionAffinity,
+		GatewayConfigurationSecretName:   gatewayConfigurationSecretName,
+		PriorityClassName:                r.APIcastCR.Spec.PriorityClassName,
+		DNSPolicy:                        r.APIcastCR.Spec.DNSPolicy,
+		DNSConfig:                        r.APIcastCR.Spec.DNSConfig,
+	}
+
+	if r.APIcastCR.Spec.ServicesFilter != nil {
+		if len(r.APIcastCR.Spec.ServicesFilter.ServiceIDs) > 0 {
+			apicastResult.EnabledServices = r.APIcastCR.Spec.ServicesFilter.ServiceIDs
+		}
+		apicastResult.ServicesFilterByURL = r.APIcastCR.Spec.ServicesFilter.URLFilter
+	}
+
+	if r.APIcastCR.Spec.ConfigurationLoadMode != nil {
+		configurationLoadMode := string(*r.APIcastCR.Spec.ConfigurationLoadMode)
+		apicastResult.ConfigurationLoadMode = &configurationLoadMode
+	}
+
+	if remoteConfiguration := r.APIcastCR.Spec.RemoteConfiguration; remoteConfiguration != nil {
+		apicastResult.RemoteConfigurationURL = &remoteConfiguration.URL
+		// The URL serves the whole configuration, so it is loaded at boot
+		// unless another mode is set
+		if apicastResult.ConfigurationLoadMode == nil {
+			configurationLoadMode := string(appsv1alpha1.ConfigurationLoadModeBoot)
+			apicastResult.ConfigurationLoadMode = &configurationLoadMode
+		}
+	}
+
+	if userProvidedSecrets.remoteConfigurationSecret != nil {
+		remoteConfigurationSecretName := userProvidedSecrets.remoteConfigurationSecret.Name
+		apicastResult.RemoteConfigurationSecretName = &remoteConfigurationSecretName
+	}
+
+	if r.APIcastCR.Spec.ManagementAPIScope != nil {
+		managementAPIScope := string(*r.APIcastCR.Spec.ManagementAPIScope)
+		apicastResult.ManagementAPIScope = &managementAPIScope
+	}
+
+	if r.APIcastCR.Spec.BackendCacheHandler != nil {
+		backendCacheHandler := string(*r.APIcastCR.Spec.BackendCacheHandler)
+		apicastResult.BackendCacheHandler = &backendCacheHandler
+	}
+
+	if volumeClaim := r.APIcastCR.Spec.EmbeddedConfigurationVolumeClaim; volumeClaim != nil {
+		apicastResult.GatewayConfigurationClaimName = &volumeClaim.ClaimName
+		apicastResult.GatewayConfigurationClaimPath = apicast.EmbeddedConfigurationSecretKey
+		if volumeClaim.Path != nil {
+			apicastResult.GatewayConfigurationClaimPath = *volumeClaim.Path
+		}
+	}
+
+	if r.APIcastCR.Spec.Upstream != nil {
+		apicastResult.UpstreamKeepaliveRequests = r.APIcastCR.Spec.Upstream.KeepaliveRequests
+	}
+
+	if r.APIcastCR.Spec.UpstreamRetry != nil {
+		apicastResult.UpstreamRetryCases = r.APIcastCR.Spec.UpstreamRetry.Cases
+	}
+
+	if userProvidedSecrets.upstreamTLSSecret != nil {
+		upstreamTLSSecretName := userProvidedSecrets.upstreamTLSSecret.Name
+		apicastResult.UpstreamTLSSecretName = &upstreamTLSSecretName
+	}
+
+	if r.APIcastCR.Spec.Reporting != nil {
+		apicastResult.ReportingThreads = r.APIcastCR.Spec.Reporting.Threads
+		apicastResult.BatcherSharedMemorySizeMiB = r.APIcastCR.Spec.Reporting.BatcherSharedMemorySizeMiB
+	}
+
+	if r.APIcastCR.Spec.Proxy != nil {
+		apicastResult.HTTPProxy = r.APIcastCR.Spec.Proxy.HTTPProxy
+		apicastResult.HTTPSProxy = r.APIcastCR.Spec.Proxy.HTTPSProxy
+		apicastResult.NoProxy = r.APIcastCR.Spec.Proxy.NoProxy
+	}
+
+	// Explicitly set the Kubernetes default so the Deployment is reconciled
+	// back when the field is removed from the APIcast resource
+	terminationGracePeriodSeconds := int64(v1.DefaultTerminationGracePeriodSeconds)
+	if r.APIcastCR.Spec.TerminationGracePeriodSeconds != nil {
+		terminationGracePeriodSeconds = *r.APIcastCR.Spec.TerminationGracePeriodSeconds
+	}
+	apicastResult.TerminationGracePeriodSeconds = &terminationGracePeriodSeconds
+
+	preStopHook := r.APIcastCR.Spec.PreStopHook
+	if preStopHook != nil && preStopHook.Enabled != nil && *preStopHook.Enabled {
+		if preStopHook.Command != nil {
+			apicastResult.PreStopCommand = preStopHook.Command
+		} else {
+			sleepSeconds := apicast.DefaultPreStopSleepSeconds
+			if preStopHook.SleepSeconds != nil {
+				sleepSeconds = *preStopHook.SleepSeconds
+			}
+			apicastResult.PreStopCommand = []string{"/bin/sh", "-c", fmt.Sprintf("sleep %d", sleepSeconds)}
+		}
+	}
+
+	apicastResult.HTTPSPort = r.APIcastCR.Spec.HTTPSPort
+	apicastResult.HTTPSVerifyDepth = r.APIcastCR.Spec.HTTPSVerifyDepth
+
+	if r.APIcastCR.Spec.AccessLogSidecar != nil {
+		accessLogFile := apicast.DefaultAccessLogFile
+		if r.APIcastCR.Spec.AccessLogSidecar.Path != nil {
+			accessLogFile = path.Clean(*r.APIcastCR.Spec.AccessLogSidecar.Path)
+		}
+		apicastResult.AccessLogFile = &accessLogFile
+
+		if forwarder := r.APIcastCR.Spec.AccessLogSidecar.Forwarder; forwarder != nil {
+			apicastResult.LogForwarder = &apicast.LogForwarder{
+				Image:   forwarder.Image,
+				Command: forwarder.Command,
+				Args:    forwarder.Args,
+			}
+		}
+	}
+
+	if r.APIcastCR.Spec.ErrorLog != nil {
+		errorLogFile := apicast.DefaultErrorLogFile
+		if r.APIcastCR.Spec.ErrorLog.Path != nil {
+			errorLogFile = path.Clean(*r.APIcastCR.Spec.ErrorLog.Path)
+		}
+		apicastResult.ErrorLogFile = &errorLogFile
+	}
+
+	apicastResult.ProxyPort = apicast.Port{Name: apicast.DefaultProxyPortName, Port: apicast.ProxyContainerPort}
+	apicastResult.ManagementPort = apicast.Port{Name: apicast.DefaultManagementPortName, Port: apicast.ManagementContainerPort}
+	apicastResult.MetricsPort = apicast.Port{Name: apicast.DefaultMetricsPortName, Port: apicast.MetricsContainerPort}
+	if ports := r.APIcastCR.Spec.Ports; ports != nil {
+		overridePort(&apicastResult.ProxyPort, ports.Proxy)
+		overridePort(&apicastResult.ManagementPort, ports.Management)
+		overridePort(&apicastResult.MetricsPort, ports.Metrics)
+		apicastResult.MetricsServicePortEnabled = ports.Metrics != nil
+	}
+
+	overrideInt32(&apicastResult.MinReadySeconds, r.APIcastCR.Spec.MinReadySeconds)
+
+	if readinessProbe := r.APIcastCR.Spec.ReadinessProbe; readinessProbe != nil {
+		timing := apicast.DefaultReadinessProbeTiming
+		overrideInt32(&timing.InitialDelaySeconds, readinessProbe.InitialDelaySeconds)
+		overrideInt32(&timing.TimeoutSeconds, readinessProbe.TimeoutSeconds)
+		overrideInt32(&timing.PeriodSeconds, readinessProbe.PeriodSeconds)
+		overrideInt32(&timing.SuccessThreshold, readinessProbe.SuccessThreshold)
+		overrideInt32(&timing.FailureThreshold, readinessProbe.FailureThreshold)
+		apicastResult.ReadinessProbeTiming = &timing
+		apicastResult.ReadinessProbePort = readinessProbe.Port
+	}
+
+	if livenessProbe := r.APIcastCR.Spec.LivenessProbe; livenessProbe != nil {
+		timing := apicast.DefaultLivenessProbeTiming
+		overrideInt32(&timing.InitialDelaySeconds, livenessProbe.InitialDelaySeconds)
+		overrideInt32(&timing.TimeoutSeconds, livenessProbe.TimeoutSeconds)
+		overrideInt32(&timing.PeriodSeconds, livenessProbe.PeriodSeconds)
+		overrideInt32(&timing.SuccessThreshold, livenessProbe.SuccessThreshold)
+		overrideInt32(&timing.FailureThreshold, livenessProbe.FailureThreshold)
+		apicastResult.LivenessProbeTiming = &timing
+		apicastResult.LivenessProbePort = livenessProbe.Port
+	}
+
+	for _, initContainer := range r.APIcastCR.Spec.InitContainers {
+		initContainerImage := image
+		if initContainer.Image != nil {
+			initContainerImage = *initContainer.Image
+		}
+		apicastResult.InitContainers = append(apicastResult.InitContainers, apicast.InitContainer{
+			Name:    initContainer.Name,
+			Image:   initContainerImage,
+			Command: initContainer.Command,
+			Args:    initContainer.Args,
+		})
+	}
+
+	for _, customPolicy := range r.APIcastCR.Spec.CustomPolicies {
+		policy := apicast.CustomPolicy{
+			Name:    customPolicy.Name,
+			Version: customPolicy.Version,
+		}
+		if customPolicy.SecretRef != nil {
+			policy.SecretName = &customPolicy.SecretRef.Name
+		}
+		if customPolicy.ConfigMapRef != nil {
+			policy.ConfigMapName = &customPolicy.ConfigMapRef.Name
+		}
+		apicastResult.CustomPolicies = append(apicastResult.CustomPolicies, policy)
+	}
+
+	for _, volume := range r.APIcastCR.Spec.Volumes {
+		apicastResult.AdditionalVolumes = append(apicastResult.AdditionalVolumes, additionalVolume(volume))
+	}
+	apicastResult.AdditionalVolumeMounts = r.APIcastCR.Spec.VolumeMounts
+
+	if networkPolicy := r.APIcastCR.Spec.NetworkPolicy; networkPolicy != nil {
+		apicastResult.NetworkPolicyRules = &apicast.NetworkPolicyRules{
+			ProxyFrom:      networkPolicy.ProxyFrom,
+			ManagementFrom: networkPolicy.ManagementFrom,
+			Egress:         networkPolicy.Egress,
+		}
+	}
+
+	for _, directory := range r.APIcastCR.Spec.PolicyLoadPath {
+		apicastResult.PolicyLoadPath = append(apicastResult.PolicyLoadPath, path.Clean(directory))
+	}
+
+	if secureMetrics := r.APIcastCR.Spec.SecureMetrics; secureMetrics != nil {
+		apicastResult.SecureMetrics = &apicast.SecureMetrics{Image: secureMetrics.Image}
+		if secureMetrics.TLSSecretRef != nil {
+			apicastResult.SecureMetrics.TLSSecretName = &secureMetrics.TLSSecretRef.Name
+		}
+		if secureMetrics.BasicAuthSecretRef != nil {
+			apicastResult.SecureMetrics.BasicAuthSecretName = &secureMetrics.BasicAuthSecretRef.Name
+		}
+	}
+
+	hotReload := r.APIcastCR.Spec.HotReloadSidecar
+	if hotReload != nil && hotReload.Enabled != nil && *hotReload.Enabled {
+		apicastResult.HotReloadImage = &hotReload.Image
+		// The sidecar reloads the gateway in place, so changes of the
+		// embedded configuration secret must not roll out new pods
+		delete(deploymentAnnotations, GatewayConfigurationSecretResverAnnotation)
+	}
+
+	// The gateway reloads the mounted configuration when its cache expires,
+	// so changes of the embedded configuration secret must not roll out new
+	// pods either
+	if reload := r.APIcastCR.Spec.ConfigurationReload; reload != nil && *reload == appsv1alpha1.ConfigurationReloadCache {
+		delete(deploymentAnnotations, GatewayConfigurationSecretResverAnnotation)
+	}
+
+	return apicastResult, err
+}
+
+// additionalVolume converts a user provided volume into a pod volume. The
+// default mode is set explicitly, as the API server defaults it, so the
+// volumes are not detected as changed on every reconciliation
+func additionalVolume(volume appsv1alpha1.APIcastVolume) v1.Volume {
+	result := v1.Volume{Name: volume.Name}
+	if volume.Secret != nil {
+		result.Secret = volume.Secret.DeepCopy()
+		if result.Secret.DefaultMode == nil {
+			defaultMode := v1.SecretVolumeSourceDefaultMode
+			result.Secret.DefaultMode = &defaultMode
+		}
+	}
+	if volume.ConfigMap != nil {
+		result.ConfigMap = volume.ConfigMap.DeepCopy()
+		if result.ConfigMap.DefaultMode == nil {
+			defaultMode := v1.ConfigMapVolumeSourceDefaultMode
+			result.ConfigMap.DefaultMode = &defaultMode
+		}
+	}
+	if volume.EmptyDir != nil {
+		result.EmptyDir = &v1.EmptyDirVolumeSource{Medium: volume.EmptyDir.Medium}
+	}
+
+	return result
+}
+
+func overrideInt32(value *int32, override *int32) {
+	if override != nil {
+		*value = *override
+	}
+}
+
+func overridePort(port *apicast.Port, portSpec *appsv1alpha1.APIcastPort) {
+	if portSpec == nil {
+		return
+	}
+	if portSpec.Name != nil {
+		port.Name = *portSpec.Name
+	}
+	if portSpec.Port != nil {
+		port.Port = *portSpec.Port
+	}
+}
+
+// propagatedLabels returns the labels of the APIcast resource selected by
+// PropagateLabels to be copied to the gateway pods
+func (r *APIcastLogicReconciler) propagatedLabels() map[string]string {
+	labels := map[string]string{}
+	for _, key := range r.APIcastCR.Spec.PropagateLabels {
+		if value, ok := r.APIcastCR.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// apicastFullName returns the name of the resources created for the APIcast
+func (r *APIcastLogicReconciler) apicastFullName() string {
+	return r.APIcastCR.ResourceName()
+}
+
+func (r *APIcastLogicReconciler) namespacedName(object metav1.Object) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      object.GetName(),
+		Namespace: object.GetNamespace(),
+	}
+}
+
+// asOwner returns an owner reference set as the tenant CR
+func asOwner(a *appsv1alpha1.APIcast) metav1.OwnerReference {
+	trueVar := true
+	return metav1.OwnerReference{
+		APIVersion: appsv1alpha1.SchemeGroupVersion.String(),
+		Kind:       appscommon.APIcastKind,
+		Name:       a.Name,
+		UID:        a.UID,
+		Controller: &trueVar,
+	}
+}
+
+func (r *APIcastLogicReconciler) setOwnerReference(obj metav1.Object) error {
+	ro, ok := obj.(runtime.Object)
+	if !ok {
+		return fmt.Errorf("is not a %T a runtime.Object, cannot call setOwnerReference", obj)
+	}
+	err := controllerutil.SetControllerReference(r.APIcastCR, obj, r.Scheme())
+
+	if err != nil {
+		r.Logger().Error(err, "Error setting OwnerReference on object. Requeuing request...",
+			"Kind", ro.GetObjectKind(),
+			"Namespace", obj.GetNamespace(),
+			"Name", obj.GetName(),
+		)
+	}
+	return err
+}
+
+func (r *APIcastLogicReconciler) reconcileDeployment(desiredDeployment appsv1.Deployment) error {
+	existingDeployment := appsv1.Deployment{}
+	err := r.Client().Get(context.TODO(), r.namespacedName(&desiredDeployment), &existingDeployment)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The annotations are recorded before the operator ones are added
+			setManagedAnnotationsAnnotation(&desiredDeployment, desiredDeployment.Annotations)
+			setManagedVolumesAnnotation(&desiredDeployment, desiredDeployment.Spec.Template.Spec.Volumes)
+			r.Logger().Info("Creating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&desiredDeployment))
+			err = r.Client().Create(context.TODO(), &desiredDeployment)
+			if err == nil {
+				r.warnHighReplicas(desiredDeployment.Spec.Replicas)
+			}
+			return err
+		}
+		return err
+	}
+
+	changed := false
+
+	replicasChanged := !reflect.DeepEqual(existingDeployment.Spec.Replicas, desiredDeployment.Spec.Replicas)
+	if replicasChanged {
+		existingDeployment.Spec.Replicas = desiredDeployment.Spec.Replicas
+		changed = true
+	}
+	if !reflect.DeepEqual(existingDeployment.Spec.RevisionHistoryLimit, desiredDeployment.Spec.RevisionHistoryLimit) {
+		existingDeployment.Spec.RevisionHistoryLimit = desiredDeployment.Spec.RevisionHistoryLimit
+		changed = true
+	}
+	if existingDeployment.Spec.MinReadySeconds != desiredDeployment.Spec.MinReadySeconds {
+		existingDeployment.Spec.MinReadySeconds = desiredDeployment.Spec.MinReadySeconds
+		changed = true
+	}
+	if !reflect.DeepEqual(existingDeployment.Spec.ProgressDeadlineSeconds, desiredDeployment.Spec.ProgressDeadlineSeconds) {
+		existingDeployment.Spec.ProgressDeadlineSeconds = desiredDeployment.Spec.ProgressDeadlineSeconds
+		changed = true
+	}
+
+	// Resume the rollout paused by the safe rollout mode now that the APIcast
+	// resource is valid again
+	if existingDeployment.Spec.Paused && r.APIcastCR.Status.IsConditionTrue(appsv1alpha1.RolloutPausedConditionType) {
+		existingDeployment.Spec.Paused = false
+		changed = true
+	}
+
+	if reconcileManagedAnnotations(&existingDeployment, desiredDeployment.Annotations) {
+		changed = true
+	}
+
+	if reconcileControllerReference(&existingDeployment, &desiredDeployment) {
+		changed = true
+	}
+
+	if r.reconcilePodTemplate(&existingDeployment, &existingDeployment.Spec.Template, &desiredDeployment.Spec.Template) {
+		changed = true
+	}
+
+	if changed {
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&existingDeployment), "ResourceVersion", existingDeployment.GetResourceVersion())
+		err = r.Client().Update(context.TODO(), &existingDeployment)
+		if err == nil && replicasChanged {
+			r.warnHighReplicas(desiredDeployment.Spec.Replicas)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// warnHighReplicas emits a warning event when the gateway Deployment is
+// scaled to an unusually high number of replicas. It is only called when the
+// replicas of the Deployment are set, so the event is not repeated on every
+// reconciliation
+func (r *APIcastLogicReconciler) warnHighReplicas(replicas *int32) {
+	if replicas != nil && *replicas > HighReplicasThreshold {
+		r.EventRecorder().Eventf(r.APIcastCR, v1.EventTypeWarning, "HighReplicas", "Replicas set to %d, which is higher than %d", *replicas, HighReplicasThreshold)
+	}
+}
+
+// reconcileDegradedCondition sets the Degraded condition from the status of
+// the gateway Deployment. The condition is left unchanged while the
+// Deployment controller has not observed its latest spec
+func (r *APIcastLogicReconciler) reconcileDegradedCondition(name string) error {
+	deployment := appsv1.Deployment{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.APIcastCR.Namespace}, &deployment)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return nil
+	}
+
+	r.APIcastCR.Status.SetCondition(degradedCondition(&deployment))
+	return nil
+}
+
+// manageService returns whether the gateway Service is created and
+// reconciled by the operator
+func (r *APIcastLogicReconciler) manageService() bool {
+	return r.APIcastCR.Spec.ManageService == nil || *r.APIcastCR.Spec.ManageService
+}
+
+// manageIngress returns whether the gateway Ingress is created and
+// reconciled by the operator
+func (r *APIcastLogicReconciler) manageIngress() bool {
+	return r.APIcastCR.Spec.ManageIngress == nil || *r.APIcastCR.Spec.ManageIngress
+}
+
+// isSuspended returns whether the gateway Deployment is scaled down to zero
+// replicas by Suspend
+func (r *APIcastLogicReconciler) isSuspended() bool {
+	return r.APIcastCR.Spec.Suspend != nil && *r.APIcastCR.Spec.Suspend
+}
+
+// reconcileSuspendedCondition reports the gateway Deployment suspended. The
+// condition is removed when it is resumed
+func (r *APIcastLogicReconciler) reconcileSuspendedCondition() {
+	if !r.isSuspended() {
+		r.APIcastCR.Status.RemoveCondition(appsv1alpha1.SuspendedConditionType)
+		return
+	}
+
+	r.APIcastCR.Status.SetCondition(appsv1alpha1.APIcastCondition{
+		Type:    appsv1alpha1.SuspendedConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  "SuspendRequested",
+		Message: "The gateway is scaled down to zero replicas",
+	})
+}
+
+// reconcileScaledDownCondition reports the gateway Deployment scaled down to
+// zero replicas, so it is clear that the gateway is not running on purpose.
+// The condition is removed when it is scaled up again
+func (r *APIcastLogicReconciler) reconcileScaledDownCondition(replicas int32) {
+	if replicas != 0 {
+		r.APIcastCR.Status.RemoveCondition(appsv1alpha1.ScaledDownConditionType)
+		return
+	}
+
+	r.APIcastCR.Status.SetCondition(appsv1alpha1.APIcastCondition{
+		Type:    appsv1alpha1.ScaledDownConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  "ZeroReplicas",
+		Message: "Replicas set to 0",
+	})
+}
+
+// degradedCondition returns the Degraded condition of the gateway Deployment.
+// It is degraded when the rollout exceeded its progress deadline, when pods
+// cannot be created, or when it does not have the minimum available replicas
+func degradedCondition(deployment *appsv1.Deployment) appsv1alpha1.APIcastCondition {
+	for _, condition := range deployment.Status.Conditions {
+		switch {
+		case condition.Type == appsv1.DeploymentProgressing && condition.Status == v1.ConditionFalse,
+			condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == v1.ConditionTrue,
+			condition.Type == appsv1.DeploymentAvailable && condition.Status == v1.ConditionFalse:
+			return appsv1alpha1.APIcastCondition{
+				Type:    appsv1alpha1.DegradedConditionType,
+				Status:  v1.ConditionTrue,
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			}
+		}
+	}
+
+	return appsv1alpha1.APIcastCondition{
+		Type:   appsv1alpha1.DegradedConditionType,
+		Status: v1.ConditionFalse,
+		Reason: "DeploymentAvailable",
+	}
+}
+
+func (r *APIcastLogicReconciler) reconcileDaemonSet(desiredDaemonSet appsv1.DaemonSet) error {
+	existingDaemonSet := appsv1.DaemonSet{}
+	err := r.Client().Get(context.TODO(), r.namespacedName(&desiredDaemonSet), &existingDaemonSet)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The annotations are recorded before the operator ones are added
+			setManagedAnnotationsAnnotation(&desiredDaemonSet, desiredDaemonSet.Annotations)
+			setManagedVolumesAnnotation(&desiredDaemonSet, desiredDaemonSet.Spec.Template.Spec.Volumes)
+			r.Logger().Info("Creating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&desiredDaemonSet))
+			err = r.Client().Create(context.TODO(), &desiredDaemonSet)
+			return err
+		}
+		return err
+	}
+
+	changed := false
+
+	if !reflect.DeepEqual(existingDaemonSet.Spec.RevisionHistoryLimit, desiredDaemonSet.Spec.RevisionHistoryLimit) {
+		existingDaemonSet.Spec.RevisionHistoryLimit = desiredDaemonSet.Spec.RevisionHistoryLimit
+		changed = true
+	}
+	if existingDaemonSet.Spec.MinReadySeconds != desiredDaemonSet.Spec.MinReadySeconds {
+		existingDaemonSet.Spec.MinReadySeconds = desiredDaemonSet.Spec.MinReadySeconds
+		changed = true
+	}
+
+	if reconcileManagedAnnotations(&existingDaemonSet, desiredDaemonSet.Annotations) {
+		changed = true
+	}
+
+	if reconcileControllerReference(&existingDaemonSet, &desiredDaemonSet) {
+		changed = true
+	}
+
+	if r.reconcilePodTemplate(&existingDaemonSet, &existingDaemonSet.Spec.Template, &desiredDaemonSet.Spec.Template) {
+		changed = true
+	}
+
+	if changed {
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&existingDaemonSet), "ResourceVersion", existingDaemonSet.GetResourceVersion())
+		err = r.Client().Update(context.TODO(), &existingDaemonSet)
+		return err
+	}
+
+	return nil
+}
+
+// reconcilePodTemplate reconciles the fields of the gateway pod template set
+// by the operator. The workload holding the template keeps the names of the
+// managed volumes in an annotation. Returns whether the template or the
+// workload annotations were changed
+func (r *APIcastLogicReconciler) reconcilePodTemplate(workload k8sutils.KubernetesObject, existingTemplate, desiredTemplate *v1.PodTemplateSpec) bool {
+	changed := false
+
+	// The gateway container is located by name, as other containers can be
+	// injected in the pod, i.e. by a service mesh
+	desiredContainer := &desiredTemplate.Spec.Containers[0]
+	gatewayIdx := findContainer(existingTemplate.Spec.Containers, desiredContainer.Name)
+	if gatewayIdx < 0 {
+		r.Logger().Info("Gateway container not found. Recreating it", "Object", k8sutils.ObjectInfo(workload), "Container", desiredContainer.Name)
+		existingTemplate.Spec.Containers = append([]v1.Container{*desiredContainer.DeepCopy()}, existingTemplate.Spec.Containers...)
+		gatewayIdx = 0
+		changed = true
+	}
+	existingContainer := &existingTemplate.Spec.Containers[gatewayIdx]
+
+	if existingContainer.Image != desiredContainer.Image {
+		existingContainer.Image = desiredContainer.Image
+		changed = true
+
+	}
+
+	if !reflect.DeepEqual(existingContainer.Command, desiredContainer.Command) {
+		existingContainer.Command = desiredContainer.Command
+		changed = true
+	}
+
+	if !reflect.DeepEqual(existingContainer.Args, desiredContainer.Args) {
+		existingContainer.Args = desiredContainer.Args
+		changed = true
+	}
+
+	if existingTemplate.Spec.ServiceAccountName != desiredTemplate.Spec.ServiceAccountName {
+		changed = true
+		existingTemplate.Spec.ServiceAccountName = desiredTemplate.Spec.ServiceAccountName
+	}
+
+	if !reflect.DeepEqual(existingTemplate.Spec.AutomountServiceAccountToken, desiredTemplate.Spec.AutomountServiceAccountToken) {
+		changed = true
+		existingTemplate.Spec.AutomountServiceAccountToken = desiredTemplate.Spec.AutomountServiceAccountToken
+	}
+
+	if existingTemplate.Spec.PriorityClassName != desiredTemplate.Spec.PriorityClassName {
+		changed = true
+		existingTemplate.Spec.PriorityClassName = desiredTemplate.Spec.PriorityClassName
+	}
+
+	if existingTemplate.Spec.DNSPolicy != desiredTemplate.Spec.DNSPolicy {
+		changed = true
+		existingTemplate.Spec.DNSPolicy = desiredTemplate.Spec.DNSPolicy
+	}
+
+	if !reflect.DeepEqual(existingTemplate.Spec.DNSConfig, desiredTemplate.Spec.DNSConfig) {
+		changed = true
+		existingTemplate.Spec.DNSConfig = desiredTemplate.Spec.DNSConfig
+	}
+
+	if !reflect.DeepEqual(existingTemplate.Spec.TerminationGracePeriodSeconds, desiredTemplate.Spec.TerminationGracePeriodSeconds) {
+		changed = true
+		existingTemplate.Spec.TerminationGracePeriodSeconds = desiredTemplate.Spec.TerminationGracePeriodSeconds
+	}
+
+	if !reflect.DeepEqual(existingTemplate.Spec.ShareProcessNamespace, desiredTemplate.Spec.ShareProcessNamespace) {
+		changed = true
+		existingTemplate.Spec.ShareProcessNamespace = desiredTemplate.Spec.ShareProcessNamespace
+	}
+
+	if !reflect.DeepEqual(existingContainer.ReadinessProbe, desiredContainer.ReadinessProbe) {
+		changed = true
+		existingContainer.ReadinessProbe = desiredContainer.ReadinessProbe
 	}
-	apicastOwnerRef := asOwner(r.APIcastCR)
 
-	var deploymentEnvironment *string
-	if r.APIcastCR.Spec.DeploymentEnvironment != nil {
-		res := string(*r.APIcastCR.Spec.DeploymentEnvironment)
-		deploymentEnvironment = &res
+	if !reflect.DeepEqual(existingContainer.LivenessProbe, desiredContainer.LivenessProbe) {
+		changed = true
+		existingContainer.LivenessProbe = desiredContainer.LivenessProbe
 	}
 
-	deploymentAnnotations := r.UserProvidedSecretResourceVersionAnnotations(userProvidedSecrets)
+	if !reflect.DeepEqual(existingContainer.Lifecycle, desiredContainer.Lifecycle) {
+		changed = true
+		existingContainer.Lifecycle = desiredContainer.Lifecycle
+	}
 
-	var adminPortalSecretName *string
-	if userProvidedSecrets.adminPortalCredentialsSecret != nil {
-		tmpAdminPortalSecretName := userProvidedSecrets.adminPortalCredentialsSecret.Name
-		adminPortalSecretName = &tmpAdminPortalSecretName
+	if !reflect.DeepEqual(existingContainer.Ports, desiredContainer.Ports) {
+		changed = true
+		existingContainer.Ports = desiredContainer.Ports
 	}
 
-	var gatewayConfigurationSecretName *string
-	if userProvidedSecrets.gatewayEmbeddedConfigSecret != nil {
-		tmpGatewayConfigurationSecretName := userProvidedSecrets.gatewayEmbeddedConfigSecret.Name
-		gatewayConfigurationSecretName = &tmpGatewayConfigurationSecretName
+	updatedTmp := ReconcileEnvVar(&existingContainer.Env, desiredContainer.Env)
+	changed = changed || updatedTmp
+
+	// The labels of the PodTemplate are set by the operator too: the selector
+	// labels and the ones propagated from the APIcast resource
+	if !reflect.DeepEqual(existingTemplate.Labels, desiredTemplate.Labels) {
+		changed = true
+		existingTemplate.Labels = desiredTemplate.Labels
 	}
 
-	image := apicast.GetDefaultImageVersion()
-	if r.APIcastCR.Spec.Image != nil {
-		image = *r.APIcastCR.Spec.Image
+	// They are annotations of the PodTemplate, part of the Spec, not part of the meta info of the Pod or Environment object itself
+	// It is not expected any controller to update them, so we use "set" approach, instead of merge.
+	// This way any removed annotation from desired (due to change in CR) will be removed in existing too.
+	if !reflect.DeepEqual(existingTemplate.Annotations, desiredTemplate.Annotations) {
+		changed = true
+		existingTemplate.Annotations = desiredTemplate.Annotations
 	}
 
-	serviceAccount := "default"
-	if r.APIcastCR.Spec.ServiceAccount != nil {
-		serviceAccount = *r.APIcastCR.Spec.ServiceAccount
+	// Volumes are merged, as other volumes can be injected in the pod too
+	previouslyManagedVolumes := managedVolumeNames(workload)
+	isManagedVolume := func(name string) bool {
+		return apicast.IsReservedVolumeName(name) || previouslyManagedVolumes[name]
+	}
+	if ReconcileVolumes(&existingTemplate.Spec.Volumes, desiredTemplate.Spec.Volumes, isManagedVolume) {
+		changed = true
+	}
+	if setManagedVolumesAnnotation(workload, desiredTemplate.Spec.Volumes) {
+		changed = true
 	}
 
-	apicastResult := apicast.APIcast{
-		DeploymentName:                   apicastFullName,
-		ServiceName:                      apicastFullName,
-		Replicas:                         int32(*r.APIcastCR.Spec.Replicas),
-		AppLabel:                         "apicast",
-		AdditionalAnnotations:            deploymentAnnotations,
-		ServiceAccountName:               serviceAccount,
-		Image:                            image,
-		ExposedHost:                      apicastExposedHost,
-		Namespace:                        r.APIcastCR.Namespace,
-		OwnerReference:                   &apicastOwnerRef,
-		AdminPortalCredentialsSecretName: adminPortalSecretName,
-		DeploymentEnvironment:            deploymentEnvironment,
-		DNSResolverAddress:               r.APIcastCR.Spec.DNSResolverAddress,
-		EnabledServices:                  r.APIcastCR.Spec.EnabledServices,
-		ConfigurationLoadMode:            r.APIcastCR.Spec.ConfigurationLoadMode,
-		LogLevel:                         r.APIcastCR.Spec.LogLevel,
-		PathRoutingEnabled:               r.APIcastCR.Spec.PathRoutingEnabled,
-		ResponseCodesIncluded:            r.APIcastCR.Spec.ResponseCodesIncluded,
-		CacheConfigurationSeconds:        r.APIcastCR.Spec.CacheConfigurationSeconds,
-		ManagementAPIScope:               r.APIcastCR.Spec.ManagementAPIScope,
-		OpenSSLPeerVerificationEnabled:   r.APIcastCR.Spec.OpenSSLPeerVerificationEnabled,
-		GatewayConfigurationSecretName:   gatewayConfigurationSecretName,
+	if !reflect.DeepEqual(existingContainer.VolumeMounts, desiredContainer.VolumeMounts) {
+		changed = true
+		existingContainer.VolumeMounts = desiredContainer.VolumeMounts
 	}
 
-	return apicastResult, err
+	if ReconcileInitContainers(&existingTemplate.Spec.InitContainers, desiredTemplate.Spec.InitContainers) {
+		changed = true
+	}
+
+	existingContainers := existingTemplate.Spec.Containers
+	gatewayContainer := existingContainers[gatewayIdx]
+	existingSidecars := append(append([]v1.Container{}, existingContainers[:gatewayIdx]...), existingContainers[gatewayIdx+1:]...)
+	if ReconcileSidecarContainers(&existingSidecars, desiredTemplate.Spec.Containers[1:], apicast.SidecarContainerNames) {
+		changed = true
+		// Keep the gateway container in its position
+		if gatewayIdx > len(existingSidecars) {
+			gatewayIdx = len(existingSidecars)
+		}
+		containers := append([]v1.Container{}, existingSidecars[:gatewayIdx]...)
+		containers = append(containers, gatewayContainer)
+		existingTemplate.Spec.Containers = append(containers, existingSidecars[gatewayIdx:]...)
+	}
+
+	return changed
 }
 
-func (r *APIcastLogicReconciler) namespacedName(object metav1.Object) types.NamespacedName {
-	return types.NamespacedName{
-		Name:      object.GetName(),
-		Namespace: object.GetNamespace(),
+// managedVolumeNames returns the names of the pod volumes set by the
+// operator in the last reconciliation of the workload
+func managedVolumeNames(workload metav1.Object) map[string]bool {
+	names := map[string]bool{}
+	value := workload.GetAnnotations()[ManagedVolumesAnnotation]
+	if value == "" {
+		return names
+	}
+	for _, name := range strings.Split(value, ",") {
+		names[name] = true
 	}
+	return names
 }
 
-func (r *APIcastLogicReconciler) initialize() (bool, error) {
-	if appliedSomeInitialization := r.applyInitialization(); appliedSomeInitialization {
-		r.Logger().Info(fmt.Sprintf("Updating %s", k8sutils.ObjectInfo(r.APIcastCR)))
-		err := r.Client().Update(context.TODO(), r.APIcastCR)
-		if err != nil {
-			return false, err
-		}
-		r.Logger().Info("APIcast resource missed optional fields. Updated CR which triggered a new reconciliation event")
-		return true, nil
+// setManagedVolumesAnnotation records the names of the pod volumes set by the
+// operator in the workload. Returns whether the annotation was changed
+func setManagedVolumesAnnotation(workload metav1.Object, volumes []v1.Volume) bool {
+	names := []string{}
+	for _, volume := range volumes {
+		names = append(names, volume.Name)
 	}
-	return false, nil
+	value := strings.Join(names, ",")
+
+	annotations := workload.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if existingValue, ok := annotations[ManagedVolumesAnnotation]; ok && existingValue == value {
+		return false
+	}
+	annotations[ManagedVolumesAnnotation] = value
+	workload.SetAnnotations(annotations)
+	return true
 }
 
-func (r *APIcastLogicReconciler) applyInitialization() bool {
-	var defaultAPIcastReplicas int64 = 1
-	appliedInitialization := false
+// reconcileManagedAnnotations sets the desired annotations in the object.
+// The ones set in a previous reconciliation and no longer desired are
+// deleted, while the annotations set by other controllers, like the revision
+// of the Deployment, are preserved. Returns whether the object was changed
+func reconcileManagedAnnotations(obj metav1.Object, desired map[string]string) bool {
+	changed := false
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	if value := annotations[ManagedAnnotationsAnnotation]; value != "" {
+		for _, key := range strings.Split(value, ",") {
+			if _, ok := desired[key]; !ok {
+				delete(annotations, key)
+				changed = true
+			}
+		}
+	}
 
-	if r.APIcastCR.Spec.Replicas == nil {
-		r.APIcastCR.Spec.Replicas = &defaultAPIcastReplicas
-		appliedInitialization = true
+	for key, desiredValue := range desired {
+		if existingValue, ok := annotations[key]; !ok || existingValue != desiredValue {
+			annotations[key] = desiredValue
+			changed = true
+		}
 	}
 
-	return appliedInitialization
+	obj.SetAnnotations(annotations)
+	if setManagedAnnotationsAnnotation(obj, desired) {
+		changed = true
+	}
+	return changed
 }
 
-// asOwner returns an owner reference set as the tenant CR
-func asOwner(a *appsv1alpha1.APIcast) metav1.OwnerReference {
-	trueVar := true
-	return metav1.OwnerReference{
-		APIVersion: appsv1alpha1.SchemeGroupVersion.String(),
-		Kind:       appscommon.APIcastKind,
-		Name:       a.Name,
-		UID:        a.UID,
-		Controller: &trueVar,
+// setManagedAnnotationsAnnotation records the keys of the annotations set by
+// the operator in the object. Returns whether the annotation was changed
+func setManagedAnnotationsAnnotation(obj metav1.Object, desired map[string]string) bool {
+	keys := []string{}
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	value := strings.Join(keys, ",")
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if annotations[ManagedAnnotationsAnnotation] == value {
+		return false
+	}
+	if value == "" {
+		delete(annotations, ManagedAnnotationsAnnotation)
+	} else {
+		annotations[ManagedAnnotationsAnnotation] = value
 	}
+	obj.SetAnnotations(annotations)
+	return true
 }
 
-func (r *APIcastLogicReconciler) setOwnerReference(obj metav1.Object) error {
-	ro, ok := obj.(runtime.Object)
-	if !ok {
-		return fmt.Errorf("is not a %T a runtime.Object, cannot call setOwnerReference", obj)
+// reconcileServiceAccount creates the ServiceAccount managed by the operator.
+// It has no fields to reconcile, as the tokens and image pull secrets are
+// added by the cluster
+func (r *APIcastLogicReconciler) reconcileServiceAccount(desiredServiceAccount v1.ServiceAccount) error {
+	existingServiceAccount := v1.ServiceAccount{}
+	err := r.Client().Get(context.TODO(), r.namespacedName(&desiredServiceAccount), &existingServiceAccount)
+	if err != nil && errors.IsNotFound(err) {
+		r.Logger().Info("Creating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&desiredServiceAccount))
+		err = r.Client().Create(context.TODO(), &desiredServiceAccount)
 	}
-	err := controllerutil.SetControllerReference(r.APIcastCR, obj, r.Scheme())
+	return err
+}
 
-	if err != nil {
-		r.Logger().Error(err, "Error setting OwnerReference on object. Requeuing request...",
-			"Kind", ro.GetObjectKind(),
-			"Namespace", obj.GetNamespace(),
-			"Name", obj.GetName(),
-		)
+// checkServiceAccountExists checks the ServiceAccount provided by the user
+// exists, as otherwise the gateway pods cannot be created
+func (r *APIcastLogicReconciler) checkServiceAccountExists(name string) error {
+	serviceAccount := v1.ServiceAccount{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.APIcastCR.Namespace}, &serviceAccount)
+	if err != nil && errors.IsNotFound(err) {
+		return validationErrorf("ServiceAccount '%s' not found", name)
 	}
 	return err
 }
 
-func (r *APIcastLogicReconciler) reconcileDeployment(desiredDeployment appsv1.Deployment) error {
-	existingDeployment := appsv1.Deployment{}
-	err := r.Client().Get(context.TODO(), r.namespacedName(&desiredDeployment), &existingDeployment)
+// checkPersistentVolumeClaimExists checks the PersistentVolumeClaim with the
+// gateway configuration exists, as otherwise the gateway pods cannot be
+// scheduled
+func (r *APIcastLogicReconciler) checkPersistentVolumeClaimExists(name string) error {
+	persistentVolumeClaim := v1.PersistentVolumeClaim{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.APIcastCR.Namespace}, &persistentVolumeClaim)
+	if err != nil && errors.IsNotFound(err) {
+		return validationErrorf("PersistentVolumeClaim '%s' not found", name)
+	}
+	return err
+}
+
+func (r *APIcastLogicReconciler) reconcileService(desiredService v1.Service) error {
+	existingService := v1.Service{}
+	err := r.Client().Get(context.TODO(), r.namespacedName(&desiredService), &existingService)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.Logger().Info(fmt.Sprintf("Creating %s", k8sutils.ObjectInfo(&desiredDeployment)))
-			err = r.Client().Create(context.TODO(), &desiredDeployment)
-			return err
+			setManagedAnnotationsAnnotation(&desiredService, desiredService.Annotations)
+			r.Logger().Info("Creating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&desiredService))
+			err = r.Client().Create(context.TODO(), &desiredService)
 		}
 		return err
 	}
 
 	changed := false
 
-	if existingDeployment.Spec.Replicas != desiredDeployment.Spec.Replicas {
-		existingDeployment.Spec.Replicas = desiredDeployment.Spec.Replicas
+	// A selector not matching the gateway pods leaves the Service without
+	// endpoints, so it is restored even when it was changed on purpose
+	if !reflect.DeepEqual(existingService.Spec.Selector, desiredService.Spec.Selector) {
+		existingService.Spec.Selector = desiredService.Spec.Selector
 		changed = true
 	}
-	if existingDeployment.Spec.Template.Spec.Containers[0].Image != desiredDeployment.Spec.Template.Spec.Containers[0].Image {
-		existingDeployment.Spec.Template.Spec.Containers[0].Image = desiredDeployment.Spec.Template.Spec.Containers[0].Image
-		changed = true
 
+	// Annotations and labels added by other tools, i.e. by the cloud
+	// provider, are preserved
+	if reconcileManagedAnnotations(&existingService, desiredService.Annotations) {
+		changed = true
 	}
-	if existingDeployment.Spec.Template.Spec.ServiceAccountName != desiredDeployment.Spec.Template.Spec.ServiceAccountName {
+
+	if reconcileControllerReference(&existingService, &desiredService) {
 		changed = true
-		existingDeployment.Spec.Template.Spec.ServiceAccountName = desiredDeployment.Spec.Template.Spec.ServiceAccountName
 	}
 
-	updatedTmp := ReconcileEnvVar(&existingDeployment.Spec.Template.Spec.Containers[0].Env, desiredDeployment.Spec.Template.Spec.Containers[0].Env)
-	changed = changed || updatedTmp
+	for key, value := range desiredService.Labels {
+		if existingService.Labels[key] != value {
+			if existingService.Labels == nil {
+				existingService.Labels = map[string]string{}
+			}
+			existingService.Labels[key] = value
+			changed = true
+		}
+	}
 
-	// They are annotations of the PodTemplate, part of the Spec, not part of the meta info of the Pod or Environment object itself
-	// It is not expected any controller to update them, so we use "set" approach, instead of merge.
-	// This way any removed annotation from desired (due to change in CR) will be removed in existing too.
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Annotations, desiredDeployment.Spec.Template.Annotations) {
+	if desiredService.Spec.Type != "" && existingService.Spec.Type != desiredService.Spec.Type {
+		existingService.Spec.Type = desiredService.Spec.Type
+		changed = true
+	}
+
+	desiredPorts := desiredService.Spec.Ports
+	if existingService.Spec.Type != v1.ServiceTypeClusterIP {
+		desiredPorts = withAllocatedNodePorts(desiredPorts, existingService.Spec.Ports)
+	}
+	if !reflect.DeepEqual(existingService.Spec.Ports, desiredPorts) {
+		existingService.Spec.Ports = desiredPorts
 		changed = true
-		existingDeployment.Spec.Template.Annotations = desiredDeployment.Spec.Template.Annotations
 	}
 
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Volumes, desiredDeployment.Spec.Template.Spec.Volumes) {
+	// The policy is empty for the ClusterIP Services, so it is cleared when
+	// the type is changed to ClusterIP
+	if existingService.Spec.ExternalTrafficPolicy != desiredService.Spec.ExternalTrafficPolicy {
+		existingService.Spec.ExternalTrafficPolicy = desiredService.Spec.ExternalTrafficPolicy
 		changed = true
-		existingDeployment.Spec.Template.Spec.Volumes = desiredDeployment.Spec.Template.Spec.Volumes
 	}
 
-	if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].VolumeMounts, desiredDeployment.Spec.Template.Spec.Containers[0].VolumeMounts) {
+	// The health check node port is only allocated for the LoadBalancer
+	// Services with the Local policy
+	if existingService.Spec.Type != v1.ServiceTypeLoadBalancer || existingService.Spec.ExternalTrafficPolicy != v1.ServiceExternalTrafficPolicyTypeLocal {
+		existingService.Spec.HealthCheckNodePort = 0
+	}
+
+	if desiredService.Spec.SessionAffinity != "" && existingService.Spec.SessionAffinity != desiredService.Spec.SessionAffinity {
+		existingService.Spec.SessionAffinity = desiredService.Spec.SessionAffinity
+		// The affinity timeout is defaulted again by the API server
+		existingService.Spec.SessionAffinityConfig = nil
 		changed = true
-		existingDeployment.Spec.Template.Spec.Containers[0].VolumeMounts = desiredDeployment.Spec.Template.Spec.Containers[0].VolumeMounts
 	}
 
 	if changed {
-		r.Logger().Info(fmt.Sprintf("Updating %s", k8sutils.ObjectInfo(&existingDeployment)))
-		err = r.Client().Update(context.TODO(), &existingDeployment)
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&existingService), "ResourceVersion", existingService.GetResourceVersion())
+		err = r.Client().Update(context.TODO(), &existingService)
+	}
+
+	return err
+}
+
+// withAllocatedNodePorts returns a copy of the desired ports with the node
+// ports allocated by the API server in the existing ports of the same name,
+// so they are not reallocated on every update
+func withAllocatedNodePorts(desired, existing []v1.ServicePort) []v1.ServicePort {
+	ports := make([]v1.ServicePort, len(desired))
+	for idx, port := range desired {
+		if port.NodePort == 0 {
+			for _, existingPort := range existing {
+				if existingPort.Name == port.Name {
+					port.NodePort = existingPort.NodePort
+				}
+			}
+		}
+		ports[idx] = port
+	}
+	return ports
+}
+
+// deleteOwnedService deletes the Service when it exists and is owned by the
+// APIcast resource, so Services not created by the operator are preserved
+func (r *APIcastLogicReconciler) deleteOwnedService(name string) error {
+	existingService := v1.Service{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.APIcastCR.Namespace}, &existingService)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
 		return err
 	}
 
+	if !metav1.IsControlledBy(&existingService, r.APIcastCR) {
+		return nil
+	}
+
+	r.Logger().Info("Deleting object", "Object", k8sutils.ObjectInfo(&existingService), "ResourceVersion", existingService.GetResourceVersion())
+	err = r.Client().Delete(context.TODO(), &existingService)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
 	return nil
 }
 
-func (r *APIcastLogicReconciler) reconcileService(desiredService v1.Service) error {
-	existingService := v1.Service{}
-	err := r.Client().Get(context.TODO(), r.namespacedName(&desiredService), &existingService)
+// deleteOwnedObject deletes the object of the kind of obj with the given name
+// in the namespace of the APIcast resource, when it exists and is owned by it.
+// It is used to delete the workload of the previous workload type
+func (r *APIcastLogicReconciler) deleteOwnedObject(name string, obj k8sutils.KubernetesObject) error {
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.APIcastCR.Namespace}, obj)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.Logger().Info(fmt.Sprintf("Creating %s", k8sutils.ObjectInfo(&desiredService)))
-			err = r.Client().Create(context.TODO(), &desiredService)
+			return nil
+		}
+		return err
+	}
+
+	if !metav1.IsControlledBy(obj, r.APIcastCR) {
+		return nil
+	}
+
+	r.Logger().Info("Deleting object", "Object", k8sutils.ObjectInfo(obj), "ResourceVersion", obj.GetResourceVersion())
+	err = r.Client().Delete(context.TODO(), obj)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// releaseOwnedObject removes the owner reference of the APIcast resource from
+// the object of the kind of obj with the given name, when it exists and is
+// owned by it. It is used to hand over the objects the operator no longer
+// manages, which are then kept when the APIcast resource is deleted
+func (r *APIcastLogicReconciler) releaseOwnedObject(name string, obj k8sutils.KubernetesObject) error {
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.APIcastCR.Namespace}, obj)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if !removeOwnerReference(obj, r.APIcastCR.UID) {
+		return nil
+	}
+
+	r.Logger().Info("Releasing object", "Object", k8sutils.ObjectInfo(obj), "ResourceVersion", obj.GetResourceVersion())
+	return r.Client().Update(context.TODO(), obj)
+}
+
+// checkCertificateAPIAvailable checks the cert-manager Certificate API is
+// served by the cluster
+func (r *APIcastLogicReconciler) checkCertificateAPIAvailable() error {
+	if r.DiscoveryClient == nil {
+		return validationErrorf("cert-manager %s API not available", apicast.CertificateAPIVersion)
+	}
+
+	resources, err := r.DiscoveryClient.ServerResourcesForGroupVersion(apicast.CertificateAPIVersion)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return validationErrorf("cert-manager %s API not available", apicast.CertificateAPIVersion)
+		}
+		return err
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Kind == apicast.CertificateKind {
+			return nil
+		}
+	}
+	return validationErrorf("cert-manager %s API not available", apicast.CertificateAPIVersion)
+}
+
+// reconcileCertificate reconciles the fields of the cert-manager Certificate
+// spec set by the operator
+func (r *APIcastLogicReconciler) reconcileCertificate(desiredCertificate *unstructured.Unstructured) error {
+	existingCertificate := &unstructured.Unstructured{}
+	existingCertificate.SetGroupVersionKind(desiredCertificate.GroupVersionKind())
+	err := r.Client().Get(context.TODO(), r.namespacedName(desiredCertificate), existingCertificate)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.Logger().Info("Creating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(desiredCertificate))
+			err = r.Client().Create(context.TODO(), desiredCertificate)
 		}
 		return err
 	}
 
+	desiredSpec, _, err := unstructured.NestedMap(desiredCertificate.Object, "spec")
+	if err != nil {
+		return err
+	}
+
+	update := false
+	for key, desiredValue := range desiredSpec {
+		existingValue, _, err := unstructured.NestedFieldCopy(existingCertificate.Object, "spec", key)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(existingValue, desiredValue) {
+			err = unstructured.SetNestedField(existingCertificate.Object, desiredValue, "spec", key)
+			if err != nil {
+				return err
+			}
+			update = true
+		}
+	}
+
+	if update {
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(existingCertificate), "ResourceVersion", existingCertificate.GetResourceVersion())
+		err = r.Client().Update(context.TODO(), existingCertificate)
+	}
+
 	return err
 }
 
+// deleteOwnedCertificate deletes the cert-manager Certificate when it exists
+// and is owned by the APIcast resource. The secret with the certificate is
+// kept, as it is owned by cert-manager
+func (r *APIcastLogicReconciler) deleteOwnedCertificate(name string) error {
+	existingCertificate := &unstructured.Unstructured{}
+	existingCertificate.SetAPIVersion(apicast.CertificateAPIVersion)
+	existingCertificate.SetKind(apicast.CertificateKind)
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.APIcastCR.Namespace}, existingCertificate)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	if !metav1.IsControlledBy(existingCertificate, r.APIcastCR) {
+		return nil
+	}
+
+	r.Logger().Info("Deleting object", "Object", k8sutils.ObjectInfo(existingCertificate), "ResourceVersion", existingCertificate.GetResourceVersion())
+	err = r.Client().Delete(context.TODO(), existingCertificate)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 func (r *APIcastLogicReconciler) reconcileIngress(desiredIngress extensions.Ingress) error {
 	existingIngress := extensions.Ingress{}
 	err := r.Client().Get(context.TODO(), r.namespacedName(&desiredIngress), &existingIngress)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			r.Logger().Info(fmt.Sprintf("Creating %s", k8sutils.ObjectInfo(&desiredIngress)))
+			setManagedAnnotationsAnnotation(&desiredIngress, desiredIngress.Annotations)
+			r.Logger().Info("Creating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&desiredIngress))
 			err = r.Client().Create(context.TODO(), &desiredIngress)
 		}
 		return err
 	}
 
-	exposedHostIdx := -1
-	for idx, rule := range existingIngress.Spec.Rules {
-		if rule.Host == r.APIcastCR.Spec.ExposedHost.Host {
-			exposedHostIdx = idx
-		}
-	}
-
 	update := false
 
-	if exposedHostIdx == -1 {
+	if !reflect.DeepEqual(existingIngress.Spec.Rules, desiredIngress.Spec.Rules) {
 		existingIngress.Spec.Rules = desiredIngress.Spec.Rules
 		update = true
 	}
@@ -534,8 +2538,16 @@ func (r *APIcastLogicReconciler) reconcileIngress(desiredIngress extensions.Ingr
 		update = true
 	}
 
+	if reconcileManagedAnnotations(&existingIngress, desiredIngress.Annotations) {
+		update = true
+	}
+
+	if reconcileControllerReference(&existingIngress, &desiredIngress) {
+		update = true
+	}
+
 	if update {
-		r.Logger().Info(fmt.Sprintf("Updating %s", k8sutils.ObjectInfo(&existingIngress)))
+		r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&existingIngress), "ResourceVersion", existingIngress.GetResourceVersion())
 		err = r.Client().Update(context.TODO(), &existingIngress)
 		if err != nil {
 			return err
@@ -544,3 +2556,69 @@ func (r *APIcastLogicReconciler) reconcileIngress(desiredIngress extensions.Ingr
 
 	return nil
 }
+
+// checkWildcardCertificates checks the certificates of the TLS entries of the
+// Ingress cover the wildcard hosts they are set for, as a certificate for a
+// single domain name would be served for all the subdomains. Secrets not
+// found yet, i.e. while cert-manager issues the certificate, are not checked
+func (r *APIcastLogicReconciler) checkWildcardCertificates(ingressTLS []extensions.IngressTLS) error {
+	for _, tls := range ingressTLS {
+		var wildcardHosts []string
+		for _, host := range tls.Hosts {
+			if appsv1alpha1.IsWildcardHost(host) {
+				wildcardHosts = append(wildcardHosts, host)
+			}
+		}
+		if len(wildcardHosts) == 0 || tls.SecretName == "" {
+			continue
+		}
+
+		secret := v1.Secret{}
+		err := r.Client().Get(context.TODO(), types.NamespacedName{Name: tls.SecretName, Namespace: r.APIcastCR.Namespace}, &secret)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		block, _ := pem.Decode(secret.Data[v1.TLSCertKey])
+		if block == nil {
+			return validationErrorf("TLS secret '%s' has no PEM encoded certificate in the '%s' key", tls.SecretName, v1.TLSCertKey)
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return validationErrorf("TLS secret '%s' has an invalid certificate: %v", tls.SecretName, err)
+		}
+
+		for _, host := range wildcardHosts {
+			// Any subdomain is matched only by a wildcard certificate
+			if certificate.VerifyHostname("wildcard"+strings.TrimPrefix(host, "*")) != nil {
+				return validationErrorf("certificate of TLS secret '%s' is not valid for the wildcard host '%s'", tls.SecretName, host)
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileNetworkPolicy reconciles the rules of the NetworkPolicy of the
+// gateway pods
+func (r *APIcastLogicReconciler) reconcileNetworkPolicy(desiredNetworkPolicy networkingv1.NetworkPolicy) error {
+	existingNetworkPolicy := networkingv1.NetworkPolicy{}
+	err := r.Client().Get(context.TODO(), r.namespacedName(&desiredNetworkPolicy), &existingNetworkPolicy)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.Logger().Info("Creating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&desiredNetworkPolicy))
+			err = r.Client().Create(context.TODO(), &desiredNetworkPolicy)
+		}
+		return err
+	}
+
+	if reflect.DeepEqual(existingNetworkPolicy.Spec, desiredNetworkPolicy.Spec) {
+		return nil
+	}
+
+	existingNetworkPolicy.Spec = desiredNetworkPolicy.Spec
+	r.Logger().Info("Updating object", "FieldManager", r.FieldManager(), "Object", k8sutils.ObjectInfo(&existingNetworkPolicy), "ResourceVersion", existingNetworkPolicy.GetResourceVersion())
+	return r.Client().Update(context.TODO(), &existingNetworkPolicy)
+}
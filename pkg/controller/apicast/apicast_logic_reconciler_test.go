@@ -0,0 +1,1526 @@
+package apicast
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/3scale/apicast-operator/pkg/apicast"
+	"github.com/3scale/apicast-operator/pkg/apis"
+	appsv1alpha1 "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1"
+	"github.com/3scale/apicast-operator/pkg/k8sutils"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func newTestAPIcast() *appsv1alpha1.APIcast {
+	replicas := int64(1)
+	return &appsv1alpha1.APIcast{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-apicast",
+			Namespace: "operator-unittest",
+		},
+		Spec: appsv1alpha1.APIcastSpec{
+			Replicas: &replicas,
+		},
+	}
+}
+
+func newTestLogicReconciler(t *testing.T, cr *appsv1alpha1.APIcast, objects ...runtime.Object) *APIcastLogicReconciler {
+	s := scheme.Scheme
+	err := apis.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := fake.NewFakeClientWithScheme(s, objects...)
+	baseReconciler := NewBaseReconciler(client, client, s, logf.Log, &record.FakeRecorder{})
+	logicReconciler := NewAPIcastLogicReconciler(baseReconciler, cr)
+	return &logicReconciler
+}
+
+func TestReconcileIngressTLS(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.Spec.ExposedHost = &appsv1alpha1.APIcastExposedHost{Host: "api.example.com"}
+	reconciler := newTestLogicReconciler(t, cr)
+
+	tlsEnabled := []extensions.IngressTLS{
+		{Hosts: []string{"api.example.com"}, SecretName: "api-example-com-tls"},
+	}
+
+	// Start without TLS, then enable it, disable it and enable it again
+	steps := [][]extensions.IngressTLS{nil, tlsEnabled, nil, tlsEnabled}
+	for idx, tls := range steps {
+		cr.Spec.ExposedHost.TLS = tls
+
+		desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = reconciler.reconcileIngress(*desiredAPIcast.Ingress())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		existingIngress := &extensions.Ingress{}
+		err = reconciler.Client().Get(context.TODO(), types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}, existingIngress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, tls, existingIngress.Spec.TLS, "Ingress TLS not reconciled in step %d", idx)
+		assert.Len(t, existingIngress.Spec.Rules, 1, "Ingress rules not reconciled in step %d", idx)
+	}
+}
+
+func TestReconcileIngressPath(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.Spec.ExposedHost = &appsv1alpha1.APIcastExposedHost{
+		Host:        "api.example.com",
+		Path:        "/gateway",
+		Annotations: map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/"},
+	}
+	reconciler := newTestLogicReconciler(t, cr)
+	reconcileIngress := func() *extensions.Ingress {
+		desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := reconciler.reconcileIngress(*desiredAPIcast.Ingress()); err != nil {
+			t.Fatal(err)
+		}
+		ingress := &extensions.Ingress{}
+		if err := reconciler.Client().Get(context.TODO(), types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}, ingress); err != nil {
+			t.Fatal(err)
+		}
+		return ingress
+	}
+
+	ingress := reconcileIngress()
+	assert.Equal(t, "/gateway", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, "/", ingress.Annotations["nginx.ingress.kubernetes.io/rewrite-target"])
+
+	// All the paths are routed again when the path is removed
+	cr.Spec.ExposedHost.Path = ""
+	cr.Spec.ExposedHost.Annotations = nil
+	ingress = reconcileIngress()
+	assert.Empty(t, ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.NotContains(t, ingress.Annotations, "nginx.ingress.kubernetes.io/rewrite-target")
+}
+
+// recordingLogger records the key/value pairs of every logged line
+type recordingLogger struct {
+	values []interface{}
+	lines  *[]map[string]interface{}
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{lines: &[]map[string]interface{}{}}
+}
+
+func (l *recordingLogger) record(keysAndValues []interface{}) {
+	line := map[string]interface{}{}
+	allValues := append(append([]interface{}{}, l.values...), keysAndValues...)
+	for idx := 0; idx+1 < len(allValues); idx += 2 {
+		line[allValues[idx].(string)] = allValues[idx+1]
+	}
+	*l.lines = append(*l.lines, line)
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record(keysAndValues)
+}
+
+func (l *recordingLogger) Enabled() bool {
+	return true
+}
+
+func (l *recordingLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.record(keysAndValues)
+}
+
+func (l *recordingLogger) V(level int) logr.InfoLogger {
+	return l
+}
+
+func (l *recordingLogger) WithValues(keysAndValues ...interface{}) logr.Logger {
+	return &recordingLogger{
+		values: append(append([]interface{}{}, l.values...), keysAndValues...),
+		lines:  l.lines,
+	}
+}
+
+func (l *recordingLogger) WithName(name string) logr.Logger {
+	return l
+}
+
+func TestReconcileLogsAPIcastContext(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "apicast-config"}
+	configSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: cr.Namespace},
+		Data:       map[string][]byte{"config.json": []byte("{}")},
+	}
+
+	s := scheme.Scheme
+	err := apis.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := newRecordingLogger()
+	client := fake.NewFakeClientWithScheme(s, cr.DeepCopy(), configSecret)
+	baseReconciler := NewBaseReconciler(client, client, s, logger, &record.FakeRecorder{})
+	reconciler := NewAPIcastLogicReconciler(baseReconciler, cr)
+
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, *logger.lines)
+	for idx, line := range *logger.lines {
+		assert.Equal(t, cr.Name, line["Name"], "Name not logged in line %d", idx)
+		assert.Equal(t, cr.Namespace, line["Namespace"], "Namespace not logged in line %d", idx)
+	}
+}
+
+// updateCountingClient counts the updates, as the fake client does not bump
+// the resource version of the updated objects
+type updateCountingClient struct {
+	client.Client
+	updates int
+}
+
+func (c *updateCountingClient) Update(ctx context.Context, obj runtime.Object) error {
+	c.updates++
+	return c.Client.Update(ctx, obj)
+}
+
+func TestReconcileDeploymentWithInjectedSidecar(t *testing.T) {
+	cr := newTestAPIcast()
+	s := scheme.Scheme
+	err := apis.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	countingClient := &updateCountingClient{Client: fake.NewFakeClientWithScheme(s)}
+	baseReconciler := NewBaseReconciler(countingClient, countingClient, s, logf.Log, &record.FakeRecorder{})
+	reconciler := NewAPIcastLogicReconciler(baseReconciler, cr)
+
+	desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reconciler.reconcileDeployment(*desiredAPIcast.Deployment())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deploymentKey := types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}
+	deployment := &appsv1.Deployment{}
+	err = reconciler.Client().Get(context.TODO(), deploymentKey, deployment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gatewayContainerName := deployment.Spec.Template.Spec.Containers[0].Name
+
+	// Simulate a service mesh injecting its proxy in front of the gateway
+	sidecar := v1.Container{Name: "istio-proxy", Image: "istio/proxyv2"}
+	sidecarVolume := v1.Volume{Name: "istio-envoy", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}
+	deployment.Spec.Template.Spec.Containers = append([]v1.Container{sidecar}, deployment.Spec.Template.Spec.Containers...)
+	deployment.Spec.Template.Spec.Volumes = append([]v1.Volume{sidecarVolume}, deployment.Spec.Template.Spec.Volumes...)
+	err = reconciler.Client().Update(context.TODO(), deployment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for step := 0; step < 2; step++ {
+		countingClient.updates = 0
+		err = reconciler.reconcileDeployment(*desiredAPIcast.Deployment())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 0, countingClient.updates, "Deployment updated in step %d", step)
+
+		deployment = &appsv1.Deployment{}
+		err = reconciler.Client().Get(context.TODO(), deploymentKey, deployment)
+		if err != nil {
+			t.Fatal(err)
+		}
+		containers := deployment.Spec.Template.Spec.Containers
+		if assert.Len(t, containers, 2, "Containers not preserved in step %d", step) {
+			assert.Equal(t, sidecar.Name, containers[0].Name)
+			assert.Equal(t, gatewayContainerName, containers[1].Name)
+		}
+		assert.Equal(t, sidecarVolume, deployment.Spec.Template.Spec.Volumes[0], "Injected volume not preserved in step %d", step)
+	}
+}
+
+func TestReconcileDeploymentRemovesClearedEnvVar(t *testing.T) {
+	cr := newTestAPIcast()
+	reconciler := newTestLogicReconciler(t, cr)
+	deploymentKey := types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}
+
+	resolverAddress := "10.0.0.10:53"
+	// Start without resolver, then set it and clear it
+	steps := []*string{nil, &resolverAddress, nil}
+	for idx, address := range steps {
+		cr.Spec.DNSResolverAddress = address
+
+		desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = reconciler.reconcileDeployment(*desiredAPIcast.Deployment())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		deployment := &appsv1.Deployment{}
+		err = reconciler.Client().Get(context.TODO(), deploymentKey, deployment)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		env := deployment.Spec.Template.Spec.Containers[0].Env
+		envVarIdx := k8sutils.FindEnvVar(env, "RESOLVER")
+		if address == nil {
+			assert.Equal(t, -1, envVarIdx, "RESOLVER env var not removed in step %d", idx)
+		} else if assert.NotEqual(t, -1, envVarIdx, "RESOLVER env var not added in step %d", idx) {
+			assert.Equal(t, *address, env[envVarIdx].Value)
+		}
+	}
+}
+
+func TestReconcileDeploymentAccessLogSidecar(t *testing.T) {
+	cr := newTestAPIcast()
+	reconciler := newTestLogicReconciler(t, cr)
+	deploymentKey := types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}
+
+	accessLogSidecar := &appsv1alpha1.APIcastAccessLogSidecar{
+		Forwarder: &appsv1alpha1.APIcastLogForwarder{Image: "fluent/fluent-bit"},
+	}
+	// Start without access log file, then enable it and disable it again
+	steps := []*appsv1alpha1.APIcastAccessLogSidecar{nil, accessLogSidecar, nil}
+	for idx, sidecar := range steps {
+		cr.Spec.AccessLogSidecar = sidecar
+
+		desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = reconciler.reconcileDeployment(*desiredAPIcast.Deployment())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		deployment := &appsv1.Deployment{}
+		err = reconciler.Client().Get(context.TODO(), deploymentKey, deployment)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		podSpec := deployment.Spec.Template.Spec
+		gatewayContainer := podSpec.Containers[0]
+		envVarIdx := k8sutils.FindEnvVar(gatewayContainer.Env, "APICAST_ACCESS_LOG_FILE")
+		hasVolume := false
+		for _, volume := range podSpec.Volumes {
+			if volume.Name == apicast.AccessLogsVolumeName {
+				hasVolume = true
+			}
+		}
+		hasVolumeMount := false
+		for _, volumeMount := range gatewayContainer.VolumeMounts {
+			if volumeMount.Name == apicast.AccessLogsVolumeName {
+				hasVolumeMount = true
+			}
+		}
+
+		if sidecar == nil {
+			assert.Equal(t, -1, envVarIdx, "APICAST_ACCESS_LOG_FILE env var not removed in step %d", idx)
+			assert.False(t, hasVolume, "Access logs volume not removed in step %d", idx)
+			assert.False(t, hasVolumeMount, "Access logs volume mount not removed in step %d", idx)
+			assert.Len(t, podSpec.Containers, 1, "Log forwarder container not removed in step %d", idx)
+		} else {
+			if assert.NotEqual(t, -1, envVarIdx, "APICAST_ACCESS_LOG_FILE env var not added in step %d", idx) {
+				assert.Equal(t, apicast.DefaultAccessLogFile, gatewayContainer.Env[envVarIdx].Value)
+			}
+			assert.True(t, hasVolume, "Access logs volume not added in step %d", idx)
+			assert.True(t, hasVolumeMount, "Access logs volume mount not added in step %d", idx)
+			assert.Len(t, podSpec.Containers, 2, "Log forwarder container not added in step %d", idx)
+		}
+	}
+}
+
+func TestReconcileSecretResolvedCondition(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "apicast-config"}
+	reconciler := newTestLogicReconciler(t, cr)
+
+	// The secret does not exist
+	_, err := reconciler.Reconcile()
+	assert.Error(t, err)
+	condition := cr.Status.GetCondition(appsv1alpha1.SecretResolvedConditionType)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+		assert.Equal(t, SecretNotFoundReason, condition.Reason)
+		assert.Contains(t, condition.Message, "apicast-config")
+	}
+
+	// The secret exists without the configuration key
+	configSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: cr.Namespace},
+		Data:       map[string][]byte{"other.json": []byte("{}")},
+	}
+	err = reconciler.Client().Create(context.TODO(), configSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = reconciler.Reconcile()
+	assert.Error(t, err)
+	condition = cr.Status.GetCondition(appsv1alpha1.SecretResolvedConditionType)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+		assert.Equal(t, SecretKeyNotFoundReason, condition.Reason)
+		assert.Contains(t, condition.Message, "config.json")
+	}
+
+	// The secret has the configuration key
+	configSecret.Data = map[string][]byte{"config.json": []byte("{}")}
+	err = reconciler.Client().Update(context.TODO(), configSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for step := 0; step < 2; step++ {
+		result, err := reconciler.Reconcile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Requeue {
+			break
+		}
+	}
+	assert.True(t, cr.Status.IsConditionTrue(appsv1alpha1.SecretResolvedConditionType))
+}
+
+func TestReconcileUpstreamTLSSecret(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.Spec.UpstreamTLS = &appsv1alpha1.APIcastUpstreamTLS{
+		ClientCertificateSecretRef: v1.LocalObjectReference{Name: "upstream-client-cert"},
+	}
+	upstreamTLSSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "upstream-client-cert", Namespace: cr.Namespace},
+		Type:       v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			v1.TLSCertKey:       []byte("cert"),
+			v1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+	reconciler := newTestLogicReconciler(t, cr, upstreamTLSSecret)
+
+	// The secret is adopted the first time
+	secret, changed, err := reconciler.reconcileUpstreamTLSSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, changed)
+	assert.Len(t, secret.GetOwnerReferences(), 1)
+
+	secret, changed, err = reconciler.reconcileUpstreamTLSSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, changed)
+
+	desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{upstreamTLSSecret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployment := desiredAPIcast.Deployment()
+	podSpec := deployment.Spec.Template.Spec
+	gatewayContainer := podSpec.Containers[0]
+
+	envVarIdx := k8sutils.FindEnvVar(gatewayContainer.Env, "APICAST_PROXY_HTTPS_CERTIFICATE")
+	if assert.NotEqual(t, -1, envVarIdx) {
+		assert.Equal(t, apicast.UpstreamTLSMountPath+"/tls.crt", gatewayContainer.Env[envVarIdx].Value)
+	}
+	envVarIdx = k8sutils.FindEnvVar(gatewayContainer.Env, "APICAST_PROXY_HTTPS_CERTIFICATE_KEY")
+	if assert.NotEqual(t, -1, envVarIdx) {
+		assert.Equal(t, apicast.UpstreamTLSMountPath+"/tls.key", gatewayContainer.Env[envVarIdx].Value)
+	}
+
+	hasVolume := false
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == apicast.UpstreamTLSVolumeName && volume.Secret != nil {
+			hasVolume = volume.Secret.SecretName == "upstream-client-cert"
+		}
+	}
+	assert.True(t, hasVolume)
+	assert.Equal(t, secret.ResourceVersion, deployment.Spec.Template.Annotations[UpstreamTLSSecretResverAnnotation])
+
+	// A secret without the private key is not resolved
+	delete(secret.Data, v1.TLSPrivateKeyKey)
+	err = reconciler.Client().Update(context.TODO(), secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = reconciler.reconcileUpstreamTLSSecret()
+	if assert.Error(t, err) {
+		assert.Equal(t, SecretKeyNotFoundReason, err.(*secretResolutionError).condition().Reason)
+	}
+}
+
+func TestReconcileServiceTrafficPolicy(t *testing.T) {
+	cr := newTestAPIcast()
+	reconciler := newTestLogicReconciler(t, cr)
+	serviceKey := types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}
+	getService := func() *v1.Service {
+		service := &v1.Service{}
+		if err := reconciler.Client().Get(context.TODO(), serviceKey, service); err != nil {
+			t.Fatal(err)
+		}
+		return service
+	}
+	reconcileService := func() {
+		desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := reconciler.reconcileService(*desiredAPIcast.Service()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reconcileService()
+	service := getService()
+	assert.Equal(t, v1.ServiceTypeClusterIP, service.Spec.Type)
+	assert.Equal(t, v1.ServiceAffinityNone, service.Spec.SessionAffinity)
+	assert.Empty(t, service.Spec.ExternalTrafficPolicy)
+
+	loadBalancer := v1.ServiceTypeLoadBalancer
+	local := v1.ServiceExternalTrafficPolicyTypeLocal
+	clientIP := v1.ServiceAffinityClientIP
+	cr.Spec.ServiceType = &loadBalancer
+	cr.Spec.ExternalTrafficPolicy = &local
+	cr.Spec.SessionAffinity = &clientIP
+	reconcileService()
+	service = getService()
+	assert.Equal(t, v1.ServiceTypeLoadBalancer, service.Spec.Type)
+	assert.Equal(t, v1.ServiceExternalTrafficPolicyTypeLocal, service.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, v1.ServiceAffinityClientIP, service.Spec.SessionAffinity)
+
+	// The node ports allocated by the API server are kept
+	for idx := range service.Spec.Ports {
+		service.Spec.Ports[idx].NodePort = int32(30000 + idx)
+	}
+	if err := reconciler.Client().Update(context.TODO(), service); err != nil {
+		t.Fatal(err)
+	}
+	reconcileService()
+	for idx, port := range getService().Spec.Ports {
+		assert.Equal(t, int32(30000+idx), port.NodePort)
+	}
+
+	// The defaults are restored when the settings are removed
+	cr.Spec.ServiceType = nil
+	cr.Spec.ExternalTrafficPolicy = nil
+	cr.Spec.SessionAffinity = nil
+	reconcileService()
+	service = getService()
+	assert.Equal(t, v1.ServiceTypeClusterIP, service.Spec.Type)
+	assert.Empty(t, service.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, v1.ServiceAffinityNone, service.Spec.SessionAffinity)
+	for _, port := range service.Spec.Ports {
+		assert.Zero(t, port.NodePort)
+	}
+}
+
+func TestReconcileServiceStandby(t *testing.T) {
+	cr := newTestAPIcast()
+	reconciler := newTestLogicReconciler(t, cr)
+	serviceKey := types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}
+	reconcileService := func() *v1.Service {
+		desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := reconciler.reconcileService(*desiredAPIcast.Service()); err != nil {
+			t.Fatal(err)
+		}
+		service := &v1.Service{}
+		if err := reconciler.Client().Get(context.TODO(), serviceKey, service); err != nil {
+			t.Fatal(err)
+		}
+		return service
+	}
+
+	standby := true
+	cr.Spec.Standby = &standby
+	service := reconcileService()
+	assert.Equal(t, "true", service.Spec.Selector[apicast.StandbyLabel])
+
+	// The traffic is switched to the pods when the option is unset
+	cr.Spec.Standby = nil
+	service = reconcileService()
+	assert.Equal(t, map[string]string{"deployment": "apicast-example-apicast"}, service.Spec.Selector)
+}
+
+func TestReconcileServiceRestoresSelector(t *testing.T) {
+	cr := newTestAPIcast()
+	reconciler := newTestLogicReconciler(t, cr)
+	serviceKey := types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}
+
+	desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	desiredService := desiredAPIcast.Service()
+	err = reconciler.reconcileService(*desiredService.DeepCopy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The selector and the labels are broken by hand
+	service := &v1.Service{}
+	err = reconciler.Client().Get(context.TODO(), serviceKey, service)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.Spec.Selector = map[string]string{"deployment": "other-apicast"}
+	service.Labels = map[string]string{"app": "other", "team": "gateway"}
+	err = reconciler.Client().Update(context.TODO(), service)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = reconciler.reconcileService(*desiredService.DeepCopy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service = &v1.Service{}
+	err = reconciler.Client().Get(context.TODO(), serviceKey, service)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, desiredService.Spec.Selector, service.Spec.Selector)
+	for key, value := range desiredService.Labels {
+		assert.Equal(t, value, service.Labels[key])
+	}
+	// The labels not managed by the operator are preserved
+	assert.Equal(t, "gateway", service.Labels["team"])
+}
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	cr := newTestAPIcast()
+	backendPort := intstr.FromInt(443)
+	cr.Spec.NetworkPolicy = &appsv1alpha1.APIcastNetworkPolicy{
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{Ports: []networkingv1.NetworkPolicyPort{{Port: &backendPort}}},
+		},
+	}
+	reconciler := newTestLogicReconciler(t, cr)
+	networkPolicyKey := types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}
+
+	desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reconciler.reconcileNetworkPolicy(*desiredAPIcast.NetworkPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	err = reconciler.Client().Get(context.TODO(), networkPolicyKey, networkPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, networkPolicy.Spec.PolicyTypes)
+	assert.Len(t, networkPolicy.Spec.Ingress, 2)
+	// The protocol defaulted by the API server is set and DNS is allowed
+	assert.Len(t, networkPolicy.Spec.Egress, 2)
+	assert.Equal(t, v1.ProtocolTCP, *networkPolicy.Spec.Egress[0].Ports[0].Protocol)
+	assert.Equal(t, intstr.FromInt(53), *networkPolicy.Spec.Egress[1].Ports[0].Port)
+
+	// The egress traffic is not restricted without egress rules
+	cr.Spec.NetworkPolicy.Egress = nil
+	desiredAPIcast, err = reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reconciler.reconcileNetworkPolicy(*desiredAPIcast.NetworkPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	networkPolicy = &networkingv1.NetworkPolicy{}
+	err = reconciler.Client().Get(context.TODO(), networkPolicyKey, networkPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, networkPolicy.Spec.PolicyTypes)
+	assert.Empty(t, networkPolicy.Spec.Egress)
+
+	// The NetworkPolicy is deleted when the option is removed
+	err = reconciler.deleteOwnedObject(networkPolicyKey.Name, &networkingv1.NetworkPolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reconciler.Client().Get(context.TODO(), networkPolicyKey, &networkingv1.NetworkPolicy{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestDegradedCondition(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue, Reason: "MinimumReplicasAvailable"},
+				{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+			},
+		},
+	}
+	condition := degradedCondition(deployment)
+	assert.Equal(t, appsv1alpha1.DegradedConditionType, condition.Type)
+	assert.Equal(t, v1.ConditionFalse, condition.Status)
+
+	// A rollout stuck on an image that cannot be pulled
+	deployment.Status.Conditions[1] = appsv1.DeploymentCondition{
+		Type:    appsv1.DeploymentProgressing,
+		Status:  v1.ConditionFalse,
+		Reason:  "ProgressDeadlineExceeded",
+		Message: `ReplicaSet "apicast-example-apicast-5d8f7c" has timed out progressing.`,
+	}
+	condition = degradedCondition(deployment)
+	assert.Equal(t, v1.ConditionTrue, condition.Status)
+	assert.Equal(t, "ProgressDeadlineExceeded", condition.Reason)
+	assert.Equal(t, deployment.Status.Conditions[1].Message, condition.Message)
+}
+
+func TestReleaseUnreferencedSecrets(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.UID = "apicast-uid"
+	cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "old-config"}
+	newConfigSecret := func(name string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace},
+			Data:       map[string][]byte{"config.json": []byte("{}")},
+		}
+	}
+	reconciler := newTestLogicReconciler(t, cr, newConfigSecret("old-config"), newConfigSecret("new-config"))
+	getSecret := func(name string) *v1.Secret {
+		secret := &v1.Secret{}
+		if err := reconciler.Client().Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, secret); err != nil {
+			t.Fatal(err)
+		}
+		return secret
+	}
+	reconcileSecrets := func() {
+		if _, _, err := reconciler.reconcileGatewayEmbbededConfig(); err != nil {
+			t.Fatal(err)
+		}
+		if err := reconciler.releaseUnreferencedSecrets(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reconcileSecrets()
+	assert.Len(t, getSecret("old-config").OwnerReferences, 1)
+	assert.Equal(t, []string{"old-config"}, cr.Status.AdoptedSecrets)
+
+	// The secret no longer referenced is released, so it is not deleted
+	// with the APIcast
+	cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "new-config"}
+	reconcileSecrets()
+	assert.Empty(t, getSecret("old-config").OwnerReferences)
+	assert.Len(t, getSecret("new-config").OwnerReferences, 1)
+	assert.Equal(t, []string{"new-config"}, cr.Status.AdoptedSecrets)
+}
+
+func TestReconcileManagedAnnotations(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": "3"},
+		},
+	}
+
+	assert.True(t, reconcileManagedAnnotations(deployment, map[string]string{"team": "gateway", "argocd.argoproj.io/sync-wave": "1"}))
+	assert.Equal(t, "gateway", deployment.Annotations["team"])
+	assert.Equal(t, "argocd.argoproj.io/sync-wave,team", deployment.Annotations[ManagedAnnotationsAnnotation])
+	assert.False(t, reconcileManagedAnnotations(deployment, map[string]string{"team": "gateway", "argocd.argoproj.io/sync-wave": "1"}))
+
+	// The removed annotations disappear, and the ones of other controllers
+	// are preserved
+	assert.True(t, reconcileManagedAnnotations(deployment, map[string]string{}))
+	assert.Equal(t, map[string]string{"deployment.kubernetes.io/revision": "3"}, deployment.Annotations)
+}
+
+func TestSuspendedAPIcast(t *testing.T) {
+	cr := newTestAPIcast()
+	replicas := int64(3)
+	cr.Spec.Replicas = &replicas
+	suspend := true
+	cr.Spec.Suspend = &suspend
+	reconciler := newTestLogicReconciler(t, cr)
+
+	desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(0), desiredAPIcast.Replicas)
+	reconciler.reconcileSuspendedCondition()
+	assert.True(t, cr.Status.IsConditionTrue(appsv1alpha1.SuspendedConditionType))
+
+	// The replicas are restored when resumed
+	cr.Spec.Suspend = nil
+	desiredAPIcast, err = reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(3), desiredAPIcast.Replicas)
+	reconciler.reconcileSuspendedCondition()
+	assert.Nil(t, cr.Status.GetCondition(appsv1alpha1.SuspendedConditionType))
+}
+
+func TestSecureMetricsSidecar(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.Spec.Ports = &appsv1alpha1.APIcastPorts{Metrics: &appsv1alpha1.APIcastPort{}}
+	cr.Spec.SecureMetrics = &appsv1alpha1.APIcastSecureMetrics{
+		Image:        "quay.io/example/metrics-proxy:latest",
+		TLSSecretRef: &v1.LocalObjectReference{Name: "metrics-tls"},
+	}
+	reconciler := newTestLogicReconciler(t, cr)
+
+	desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := desiredAPIcast.Deployment().Spec.Template
+	idx := findContainer(template.Spec.Containers, apicast.SecureMetricsContainerName)
+	if assert.True(t, idx >= 0) {
+		assert.Equal(t, "quay.io/example/metrics-proxy:latest", template.Spec.Containers[idx].Image)
+		assert.Len(t, template.Spec.Containers[idx].VolumeMounts, 1)
+	}
+	assert.Equal(t, "9443", template.Annotations["prometheus.io/port"])
+	assert.Equal(t, "https", template.Annotations["prometheus.io/scheme"])
+
+	// The metrics port of the Service points to the sidecar
+	for _, port := range desiredAPIcast.Service().Spec.Ports {
+		if port.Name == "metrics" {
+			assert.Equal(t, int(apicast.SecureMetricsContainerPort), port.TargetPort.IntValue())
+		}
+	}
+}
+
+func TestConfigurationJitterInitContainer(t *testing.T) {
+	cr := newTestAPIcast()
+	cacheSeconds := int64(300)
+	jitterSeconds := int64(60)
+	cr.Spec.CacheConfigurationSeconds = &cacheSeconds
+	cr.Spec.CacheConfigurationJitterSeconds = &jitterSeconds
+	reconciler := newTestLogicReconciler(t, cr)
+
+	desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The delay runs right before the gateway starts, after the init
+	// containers of the user
+	initContainers := desiredAPIcast.Deployment().Spec.Template.Spec.InitContainers
+	if assert.Len(t, initContainers, 1) {
+		assert.Equal(t, apicast.ConfigurationJitterContainerName, initContainers[0].Name)
+		assert.Equal(t, desiredAPIcast.Deployment().Spec.Template.Spec.Containers[0].Image, initContainers[0].Image)
+		assert.Equal(t, []string{"/bin/bash", "-c", "sleep $((RANDOM % 61))"}, initContainers[0].Command)
+	}
+}
+
+func TestPodSelector(t *testing.T) {
+	prefix := "gw-"
+	suffix := "-edge"
+	cases := []struct {
+		name     string
+		prefix   *string
+		suffix   *string
+		expected string
+	}{
+		{"default", nil, nil, "deployment=apicast-example-apicast"},
+		{"prefix", &prefix, nil, "deployment=gw-example-apicast"},
+		{"prefix and suffix", &prefix, &suffix, "deployment=gw-example-apicast-edge"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newTestAPIcast()
+			cr.Spec.ResourceNamePrefix = tc.prefix
+			cr.Spec.ResourceNameSuffix = tc.suffix
+			reconciler := newTestLogicReconciler(t, cr)
+
+			desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.expected, desiredAPIcast.PodSelector())
+			assert.Equal(t, desiredAPIcast.Deployment().Spec.Template.Labels["deployment"], desiredAPIcast.Deployment().Spec.Selector.MatchLabels["deployment"])
+		})
+	}
+}
+
+func TestReconcileCrossNamespaceAdminPortalCredentials(t *testing.T) {
+	adoptReferencedSecrets := false
+	cases := []struct {
+		name              string
+		allowedNamespaces []string
+		valid             bool
+	}{
+		{"namespace not allowed by default", nil, false},
+		{"namespace not allowed", []string{"other-credentials"}, false},
+		{"namespace allowed", []string{"3scale-credentials"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newTestAPIcast()
+			cr.Spec.AdminPortalCredentialsRef = &v1.SecretReference{Name: "central-credentials", Namespace: "3scale-credentials"}
+			cr.Spec.AdoptReferencedSecrets = &adoptReferencedSecrets
+			sourceSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "central-credentials", Namespace: "3scale-credentials"},
+				Data: map[string][]byte{
+					apicast.AdminPortalURLAttributeName: []byte("https://token@3scale-admin.example.com"),
+					"unrelated":                         []byte("secret"),
+				},
+			}
+			reconciler := newTestLogicReconciler(t, cr, sourceSecret, cr.DeepCopy())
+			reconciler.BaseReconciler = reconciler.WithAdminPortalCredentialsNamespaces(tc.allowedNamespaces)
+
+			// The first reconciliations add the finalizer and annotate the
+			// source secret, the last one copies it
+			_, changed, err := reconciler.reconcileAdminPortalCredentials()
+			if !tc.valid {
+				assert.Error(t, err)
+				assert.True(t, isValidationError(err))
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.True(t, changed)
+			assert.Equal(t, []string{WatchedSecretFinalizer}, cr.Finalizers)
+
+			_, changed, err = reconciler.reconcileAdminPortalCredentials()
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.True(t, changed)
+
+			copiedSecret, _, err := reconciler.reconcileAdminPortalCredentials()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			annotatedSecret := &v1.Secret{}
+			err = reconciler.Client().Get(context.TODO(), types.NamespacedName{Name: "central-credentials", Namespace: "3scale-credentials"}, annotatedSecret)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The secret is annotated even if it is not adopted, as the
+			// annotation maps its changes to the APIcast
+			assert.Equal(t, "operator-unittest/example-apicast", annotatedSecret.Annotations[AdminPortalCredentialsWatchedByAnnotation])
+			assert.Empty(t, annotatedSecret.OwnerReferences)
+
+			assert.Equal(t, cr.Namespace, copiedSecret.Namespace)
+			assert.Equal(t, map[string][]byte{apicast.AdminPortalURLAttributeName: []byte("https://token@3scale-admin.example.com")}, copiedSecret.Data)
+		})
+	}
+}
+
+func newTestCertificate(t *testing.T, dnsNames ...string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCheckWildcardCertificates(t *testing.T) {
+	cr := newTestAPIcast()
+	wildcardSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "wildcard-tls", Namespace: cr.Namespace},
+		Data:       map[string][]byte{v1.TLSCertKey: newTestCertificate(t, "*.apis.example.com")},
+	}
+	singleHostSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "single-host-tls", Namespace: cr.Namespace},
+		Data:       map[string][]byte{v1.TLSCertKey: newTestCertificate(t, "tenant.apis.example.com")},
+	}
+	reconciler := newTestLogicReconciler(t, cr, wildcardSecret, singleHostSecret)
+
+	wildcardHosts := []string{"*.apis.example.com"}
+	assert.NoError(t, reconciler.checkWildcardCertificates([]extensions.IngressTLS{{Hosts: wildcardHosts, SecretName: "wildcard-tls"}}))
+	assert.Error(t, reconciler.checkWildcardCertificates([]extensions.IngressTLS{{Hosts: wildcardHosts, SecretName: "single-host-tls"}}))
+	// The certificate of single hosts is not checked
+	assert.NoError(t, reconciler.checkWildcardCertificates([]extensions.IngressTLS{{Hosts: []string{"api.example.com"}, SecretName: "wildcard-tls"}}))
+	// The secret may not have been issued yet
+	assert.NoError(t, reconciler.checkWildcardCertificates([]extensions.IngressTLS{{Hosts: wildcardHosts, SecretName: "missing-tls"}}))
+}
+
+func TestReleaseOwnedObject(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.UID = types.UID("apicast-uid")
+	isController := true
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "apicast-example-apicast",
+			Namespace: cr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps.3scale.net/v1alpha1", Kind: "APIcast", Name: cr.Name, UID: cr.UID, Controller: &isController},
+			},
+		},
+	}
+	reconciler := newTestLogicReconciler(t, cr, service)
+
+	err := reconciler.releaseOwnedObject(service.Name, &v1.Service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The Service is kept, but it is no longer deleted with the APIcast
+	existingService := &v1.Service{}
+	err = reconciler.Client().Get(context.TODO(), types.NamespacedName{Name: service.Name, Namespace: cr.Namespace}, existingService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, existingService.OwnerReferences)
+
+	// Missing objects are ignored
+	assert.NoError(t, reconciler.releaseOwnedObject("missing", &extensions.Ingress{}))
+}
+
+func TestGeneratedObjectsControlledByAPIcast(t *testing.T) {
+	cr := newTestAPIcast()
+	cr.UID = types.UID("apicast-uid")
+	cr.Spec.ExposedHost = &appsv1alpha1.APIcastExposedHost{
+		Host:        "api.example.com",
+		CertManager: &appsv1alpha1.APIcastCertManager{ClusterIssuer: "letsencrypt"},
+	}
+	cr.Spec.NetworkPolicy = &appsv1alpha1.APIcastNetworkPolicy{}
+	reconciler := newTestLogicReconciler(t, cr)
+
+	desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objects := []metav1.Object{
+		desiredAPIcast.Deployment(),
+		desiredAPIcast.DaemonSet(),
+		desiredAPIcast.Service(),
+		desiredAPIcast.ManagementService(),
+		desiredAPIcast.ServiceAccount(),
+		desiredAPIcast.Ingress(),
+		desiredAPIcast.Certificate(),
+		desiredAPIcast.NetworkPolicy(),
+	}
+	for _, obj := range objects {
+		controller := metav1.GetControllerOf(obj)
+		if assert.NotNil(t, controller, obj.GetName()) {
+			assert.Equal(t, asOwner(cr), *controller, obj.GetName())
+		}
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	cases := []struct {
+		name         string
+		prefix       string
+		suffix       string
+		expectedName string
+	}{
+		{"empty prefix", "", "", "example-apicast"},
+		{"suffix", "apicast-", "-v2", "apicast-example-apicast-v2"},
+		{"uppercase prefix", "GW-", "", ""},
+		{"suffix ending with dash", "apicast-", "-", ""},
+		{"too long", "apicast-", "-" + strings.Repeat("x", 50), ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newTestAPIcast()
+			cr.Spec.ResourceNamePrefix = &tc.prefix
+			cr.Spec.ResourceNameSuffix = &tc.suffix
+			reconciler := newTestLogicReconciler(t, cr)
+
+			if tc.expectedName == "" {
+				_, err := reconciler.Reconcile()
+				assert.True(t, isValidationError(err))
+				return
+			}
+			desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.expectedName, desiredAPIcast.Deployment().Name)
+			assert.Equal(t, tc.expectedName, desiredAPIcast.Service().Name)
+		})
+	}
+}
+
+func TestServicesFilter(t *testing.T) {
+	urlFilter := `^https://.*\.example\.com$`
+	cases := []struct {
+		name           string
+		servicesFilter *appsv1alpha1.APIcastServicesFilter
+		expectedEnv    map[string]string
+	}{
+		{"service IDs", &appsv1alpha1.APIcastServicesFilter{ServiceIDs: []string{"1", "2"}}, map[string]string{"APICAST_SERVICES_LIST": "1,2"}},
+		{"URL filter", &appsv1alpha1.APIcastServicesFilter{URLFilter: &urlFilter}, map[string]string{"APICAST_SERVICES_FILTER_BY_URL": urlFilter}},
+		{"both", &appsv1alpha1.APIcastServicesFilter{ServiceIDs: []string{"1"}, URLFilter: &urlFilter}, map[string]string{"APICAST_SERVICES_LIST": "1", "APICAST_SERVICES_FILTER_BY_URL": urlFilter}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newTestAPIcast()
+			cr.Spec.ServicesFilter = tc.servicesFilter
+			reconciler := newTestLogicReconciler(t, cr)
+
+			desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			servicesEnv := map[string]string{}
+			for _, envVar := range desiredAPIcast.Deployment().Spec.Template.Spec.Containers[0].Env {
+				if strings.HasPrefix(envVar.Name, "APICAST_SERVICES_") {
+					servicesEnv[envVar.Name] = envVar.Value
+				}
+			}
+			assert.Equal(t, tc.expectedEnv, servicesEnv)
+		})
+	}
+}
+
+func TestReconcileInvalidEmbeddedConfiguration(t *testing.T) {
+	cases := []struct {
+		name          string
+		configuration string
+		valid         bool
+	}{
+		{"object", `{"services":[]}`, true},
+		{"truncated", `{"services":[`, false},
+		{"list", `[]`, false},
+		{"null", `null`, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newTestAPIcast()
+			cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "apicast-config"}
+			configSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: cr.Namespace},
+				Data:       map[string][]byte{"config.json": []byte(tc.configuration)},
+			}
+			reconciler := newTestLogicReconciler(t, cr, configSecret)
+
+			_, err := reconciler.getEmbeddedConfigSecret(cr.Spec.EmbeddedConfigurationSecretRef, "EmbeddedConfigurationSecretRef")
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, isValidationError(err))
+				assert.Contains(t, err.Error(), "apicast-config")
+			}
+		})
+	}
+}
+
+func TestReconcileDeploymentGatewayContainer(t *testing.T) {
+	cr := newTestAPIcast()
+	reconciler := newTestLogicReconciler(t, cr)
+
+	desiredAPIcast, err := reconciler.internalAPIcast(&apicastUserProvidedSecrets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	desiredDeployment := desiredAPIcast.Deployment()
+	gatewayName := desiredDeployment.Spec.Template.Spec.Containers[0].Name
+	injectedContainer := v1.Container{Name: "istio-proxy", Image: "docker.io/istio/proxyv2:1.1.0"}
+
+	cases := []struct {
+		name               string
+		existingContainers []v1.Container
+		expectedContainers []string
+	}{
+		{"injected before the gateway", []v1.Container{injectedContainer, {Name: gatewayName, Image: "quay.io/3scale/apicast:old"}}, []string{"istio-proxy", gatewayName}},
+		{"gateway removed", []v1.Container{injectedContainer}, []string{gatewayName, "istio-proxy"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			existingDeployment := desiredDeployment.DeepCopy()
+			existingDeployment.Spec.Template.Spec.Containers = tc.existingContainers
+			reconciler := newTestLogicReconciler(t, cr, existingDeployment)
+
+			err := reconciler.reconcileDeployment(*desiredDeployment.DeepCopy())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			deployment := &appsv1.Deployment{}
+			err = reconciler.Client().Get(context.TODO(), types.NamespacedName{Name: desiredDeployment.Name, Namespace: cr.Namespace}, deployment)
+			if err != nil {
+				t.Fatal(err)
+			}
+			containers := deployment.Spec.Template.Spec.Containers
+			var names []string
+			for _, container := range containers {
+				names = append(names, container.Name)
+			}
+			// The gateway container is updated, and the injected container
+			// is kept untouched
+			assert.Equal(t, tc.expectedContainers, names)
+			gatewayIdx := findContainer(containers, gatewayName)
+			assert.Equal(t, desiredAPIcast.Image, containers[gatewayIdx].Image)
+			assert.Equal(t, injectedContainer, containers[findContainer(containers, "istio-proxy")])
+		})
+	}
+}
+
+// reconcileUntilDone reconciles the APIcast until no requeue is requested,
+// as the referenced secrets are adopted in separate reconciliations
+func reconcileUntilDone(reconciler *APIcastLogicReconciler) error {
+	for step := 0; step < 5; step++ {
+		result, err := reconciler.Reconcile()
+		if err != nil || !result.Requeue {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestReconcileSafeRollout(t *testing.T) {
+	safeRollout := true
+	wildcardHost := "*.apis.example.com"
+	cr := newTestAPIcast()
+	cr.Spec.SafeRollout = &safeRollout
+	cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "apicast-config"}
+	cr.Spec.ExposedHost = &appsv1alpha1.APIcastExposedHost{
+		Host: wildcardHost,
+		TLS:  []extensions.IngressTLS{{Hosts: []string{wildcardHost}, SecretName: "wildcard-tls"}},
+	}
+	configSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: cr.Namespace},
+		Data:       map[string][]byte{"config.json": []byte("{}")},
+	}
+	tlsSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "wildcard-tls", Namespace: cr.Namespace},
+		Data:       map[string][]byte{v1.TLSCertKey: newTestCertificate(t, wildcardHost)},
+	}
+	reconciler := newTestLogicReconciler(t, cr, configSecret, tlsSecret)
+
+	getDeployment := func() *appsv1.Deployment {
+		deployment := &appsv1.Deployment{}
+		err := reconciler.Client().Get(context.TODO(), types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}, deployment)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return deployment
+	}
+	logLevelSet := func(deployment *appsv1.Deployment) bool {
+		for _, envVar := range deployment.Spec.Template.Spec.Containers[0].Env {
+			if envVar.Name == "APICAST_LOG_LEVEL" {
+				return true
+			}
+		}
+		return false
+	}
+
+	err := reconcileUntilDone(reconciler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, getDeployment().Spec.Paused)
+
+	// The certificate is checked before the Deployment is reconciled, so the
+	// rollout of the new pod template is not started
+	logLevel := "debug"
+	cr.Spec.LogLevel = &logLevel
+	tlsSecret.Data[v1.TLSCertKey] = newTestCertificate(t, "api.example.com")
+	err = reconciler.Client().Update(context.TODO(), tlsSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for step := 0; step < 2; step++ {
+		_, err = reconciler.Reconcile()
+		assert.True(t, isValidationError(err), "validation error not returned in step %d", step)
+		deployment := getDeployment()
+		assert.True(t, deployment.Spec.Paused, "rollout not paused in step %d", step)
+		assert.False(t, logLevelSet(deployment), "pod template updated in step %d", step)
+		assert.True(t, cr.Status.IsConditionTrue(appsv1alpha1.RolloutPausedConditionType))
+	}
+
+	// The rollout is resumed with the new pod template once the APIcast is
+	// valid again
+	tlsSecret.Data[v1.TLSCertKey] = newTestCertificate(t, wildcardHost)
+	err = reconciler.Client().Update(context.TODO(), tlsSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reconcileUntilDone(reconciler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployment := getDeployment()
+	assert.False(t, deployment.Spec.Paused)
+	assert.True(t, logLevelSet(deployment))
+	assert.Nil(t, cr.Status.GetCondition(appsv1alpha1.RolloutPausedConditionType))
+}
+
+func TestReconcileSafeRolloutMissingSecret(t *testing.T) {
+	cases := []struct {
+		name          string
+		safeRollout   bool
+		expectedPause bool
+	}{
+		{"enabled", true, true},
+		{"disabled", false, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newTestAPIcast()
+			cr.Spec.SafeRollout = &tc.safeRollout
+			cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "apicast-config"}
+			configSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: cr.Namespace},
+				Data:       map[string][]byte{"config.json": []byte("{}")},
+			}
+			reconciler := newTestLogicReconciler(t, cr, configSecret)
+			err := reconcileUntilDone(reconciler)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = reconciler.Client().Delete(context.TODO(), configSecret)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = reconciler.Reconcile()
+			assert.True(t, isValidationError(err))
+
+			deployment := &appsv1.Deployment{}
+			err = reconciler.Client().Get(context.TODO(), types.NamespacedName{Name: "apicast-example-apicast", Namespace: cr.Namespace}, deployment)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.expectedPause, deployment.Spec.Paused)
+			assert.Equal(t, tc.expectedPause, cr.Status.IsConditionTrue(appsv1alpha1.RolloutPausedConditionType))
+			assert.False(t, cr.Status.IsConditionTrue(appsv1alpha1.SecretResolvedConditionType))
+		})
+	}
+}
+
+func TestReconcileHighReplicasEvent(t *testing.T) {
+	cr := newTestAPIcast()
+	replicas := int64(HighReplicasThreshold + 1)
+	cr.Spec.Replicas = &replicas
+	cr.Spec.EmbeddedConfigurationSecretRef = &v1.LocalObjectReference{Name: "apicast-config"}
+	configSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: cr.Namespace},
+		Data:       map[string][]byte{"config.json": []byte("{}")},
+	}
+	s := scheme.Scheme
+	err := apis.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := fake.NewFakeClientWithScheme(s, configSecret)
+	recorder := record.NewFakeRecorder(10)
+	logicReconciler := NewAPIcastLogicReconciler(NewBaseReconciler(client, client, s, logf.Log, recorder), cr)
+	reconciler := &logicReconciler
+
+	// The event is emitted when the Deployment is created, and not repeated
+	// in the following reconciliations
+	for step := 0; step < 3; step++ {
+		err = reconcileUntilDone(reconciler)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "HighReplicas")
+
+	// It is emitted again when the replicas change
+	replicas++
+	err = reconcileUntilDone(reconciler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, recorder.Events, 1)
+	<-recorder.Events
+
+	// And not when they are scaled down below the threshold
+	replicas = HighReplicasThreshold
+	err = reconcileUntilDone(reconciler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, recorder.Events, 0)
+}
+
+func TestReconcileRemoteConfigurationCredentials(t *testing.T) {
+	cases := []struct {
+		name     string
+		username string
+		password string
+		valid    bool
+	}{
+		{"plain", "apicast", "secret", true},
+		{"URL-encoded", "apicast%40example.com", "p%40ss%3Aword%2F%25%23", true},
+		{"at sign", "apicast@example.com", "secret", false},
+		{"colon", "apicast", "pass:word", false},
+		{"slash", "apicast", "pass/word", false},
+		{"fragment", "apicast", "pass#word", false},
+		{"invalid escape", "apicast", "100%", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newTestAPIcast()
+			cr.Spec.RemoteConfiguration = &appsv1alpha1.APIcastRemoteConfiguration{
+				URL:                  "https://config.example.com/config.json",
+				CredentialsSecretRef: &v1.LocalObjectReference{Name: "config-credentials"},
+			}
+			credentialsSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "config-credentials", Namespace: cr.Namespace},
+				Data: map[string][]byte{
+					v1.BasicAuthUsernameKey: []byte(tc.username),
+					v1.BasicAuthPasswordKey: []byte(tc.password),
+				},
+			}
+			reconciler := newTestLogicReconciler(t, cr, credentialsSecret)
+
+			err := reconcileUntilDone(reconciler)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, isValidationError(err))
+				assert.True(t, cr.Status.IsConditionTrue(appsv1alpha1.InvalidConditionType))
+			}
+		})
+	}
+}
+
+func TestReleaseWatchedSecret(t *testing.T) {
+	cases := []struct {
+		name    string
+		release func(*APIcastLogicReconciler) error
+	}{
+		{"reference changed", func(reconciler *APIcastLogicReconciler) error {
+			reconciler.APIcastCR.Spec.AdminPortalCredentialsRef = &v1.SecretReference{Name: "admin-portal"}
+			return reconciler.releaseUnreferencedSecrets()
+		}},
+		{"deleted", func(reconciler *APIcastLogicReconciler) error {
+			return reconciler.Finalize()
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newTestAPIcast()
+			cr.Spec.AdminPortalCredentialsRef = &v1.SecretReference{Name: "central-credentials", Namespace: "3scale-credentials"}
+			sourceSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "central-credentials",
+					Namespace:   "3scale-credentials",
+					Annotations: map[string]string{AdminPortalCredentialsWatchedByAnnotation: "other/apicast"},
+				},
+				Data: map[string][]byte{apicast.AdminPortalURLAttributeName: []byte("https://token@3scale-admin.example.com")},
+			}
+			reconciler := newTestLogicReconciler(t, cr, sourceSecret, cr.DeepCopy())
+			reconciler.BaseReconciler = reconciler.WithAdminPortalCredentialsNamespaces([]string{"3scale-credentials"})
+			getSourceSecret := func() *v1.Secret {
+				secret := &v1.Secret{}
+				err := reconciler.Client().Get(context.TODO(), types.NamespacedName{Name: "central-credentials", Namespace: "3scale-credentials"}, secret)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return secret
+			}
+
+			for step := 0; step < 3; step++ {
+				_, _, err := reconciler.reconcileAdminPortalCredentials()
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := reconciler.releaseUnreferencedSecrets()
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, "3scale-credentials/central-credentials", cr.Status.WatchedSecret)
+			assert.Equal(t, "operator-unittest/example-apicast,other/apicast", getSourceSecret().Annotations[AdminPortalCredentialsWatchedByAnnotation])
+
+			// Only the APIcast is removed from the annotation, and then its
+			// finalizer
+			err = tc.release(reconciler)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, "other/apicast", getSourceSecret().Annotations[AdminPortalCredentialsWatchedByAnnotation])
+			assert.Empty(t, cr.Finalizers)
+			storedAPIcast := &appsv1alpha1.APIcast{}
+			err = reconciler.Client().Get(context.TODO(), types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, storedAPIcast)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Empty(t, storedAPIcast.Finalizers)
+		})
+	}
+}
+
+func TestRemoveWatchedByAnnotation(t *testing.T) {
+	cr := newTestAPIcast()
+	secret := &v1.Secret{}
+
+	assert.False(t, removeWatchedByAnnotation(secret, cr))
+
+	assert.True(t, ensureWatchedByAnnotation(secret, cr))
+	secret.Annotations[AdminPortalCredentialsWatchedByAnnotation] += ",other/apicast"
+	assert.True(t, removeWatchedByAnnotation(secret, cr))
+	assert.Equal(t, "other/apicast", secret.Annotations[AdminPortalCredentialsWatchedByAnnotation])
+	assert.False(t, removeWatchedByAnnotation(secret, cr))
+
+	// The annotation is removed with the last APIcast
+	assert.True(t, ensureWatchedByAnnotation(secret, cr))
+	cr.Namespace = "other"
+	cr.Name = "apicast"
+	assert.True(t, removeWatchedByAnnotation(secret, cr))
+	assert.Equal(t, "operator-unittest/example-apicast", secret.Annotations[AdminPortalCredentialsWatchedByAnnotation])
+	cr = newTestAPIcast()
+	assert.True(t, removeWatchedByAnnotation(secret, cr))
+	_, ok := secret.Annotations[AdminPortalCredentialsWatchedByAnnotation]
+	assert.False(t, ok)
+}
@@ -1,11 +1,22 @@
 package apicast
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/3scale/apicast-operator/version"
 )
 
+// DefaultFieldManager is the name identifying the operator as the manager of
+// the fields it writes to the objects
+const DefaultFieldManager = "apicast-operator"
+
 type BaseReconciler struct {
 	// client should be a split client that reads objects from
 	// the cache and writes to the Kubernetes APIServer
@@ -15,14 +26,22 @@ type BaseReconciler struct {
 	apiClientReader client.Reader
 	scheme          *runtime.Scheme
 	logger          logr.Logger
+	eventRecorder   record.EventRecorder
+	fieldManager    string
+	// adminPortalCredentialsNamespaces are the namespaces, other than the
+	// one of the APIcast resource, from which the admin portal credentials
+	// secrets can be read
+	adminPortalCredentialsNamespaces []string
 }
 
-func NewBaseReconciler(client client.Client, apiClientReader client.Reader, scheme *runtime.Scheme, logger logr.Logger) BaseReconciler {
+func NewBaseReconciler(client client.Client, apiClientReader client.Reader, scheme *runtime.Scheme, logger logr.Logger, eventRecorder record.EventRecorder) BaseReconciler {
 	return BaseReconciler{
 		client:          client,
 		apiClientReader: apiClientReader,
 		scheme:          scheme,
 		logger:          logger,
+		eventRecorder:   eventRecorder,
+		fieldManager:    DefaultFieldManager,
 	}
 }
 
@@ -41,3 +60,73 @@ func (b *BaseReconciler) Scheme() *runtime.Scheme {
 func (b *BaseReconciler) Logger() logr.Logger {
 	return b.logger
 }
+
+func (b *BaseReconciler) EventRecorder() record.EventRecorder {
+	return b.eventRecorder
+}
+
+// FieldManager returns the name of the manager of the fields written by the
+// reconciler, as recorded by the API server in the managed fields of the
+// objects
+func (b *BaseReconciler) FieldManager() string {
+	return b.fieldManager
+}
+
+// WithFieldManager returns a copy of the reconciler with the given field
+// manager name
+func (b BaseReconciler) WithFieldManager(fieldManager string) BaseReconciler {
+	b.fieldManager = fieldManager
+	return b
+}
+
+// AdminPortalCredentialsNamespaces returns the namespaces, other than the one
+// of the APIcast resource, from which the admin portal credentials secrets can
+// be read
+func (b *BaseReconciler) AdminPortalCredentialsNamespaces() []string {
+	return b.adminPortalCredentialsNamespaces
+}
+
+// WithAdminPortalCredentialsNamespaces returns a copy of the reconciler
+// allowed to read the admin portal credentials secrets of the given
+// namespaces
+func (b BaseReconciler) WithAdminPortalCredentialsNamespaces(namespaces []string) BaseReconciler {
+	b.adminPortalCredentialsNamespaces = namespaces
+	return b
+}
+
+// WithValues returns a copy of the reconciler whose logger adds the given
+// key/value pairs to every log line
+func (b BaseReconciler) WithValues(keysAndValues ...interface{}) BaseReconciler {
+	b.logger = b.logger.WithValues(keysAndValues...)
+	return b
+}
+
+// VerbosityLogger returns the logger of the given verbosity level. Levels up
+// to the one in the LogVerbosityAnnotation of the object are logged with the
+// base level, so the verbosity can be raised for a single object
+func VerbosityLogger(logger logr.Logger, obj metav1.Object, level int) logr.InfoLogger {
+	objectLevel, err := strconv.Atoi(obj.GetAnnotations()[LogVerbosityAnnotation])
+	if err == nil && level <= objectLevel {
+		return logger
+	}
+	return logger.V(level)
+}
+
+// UserAgent returns the user agent of the requests of the operator to the API
+// server. The client libraries do not support setting the field manager of the
+// requests, so the API server takes it from the user agent instead
+func UserAgent(fieldManager string) string {
+	return fieldManager + "/" + version.Version
+}
+
+// FieldManagerFromUserAgent returns the field manager name the API server
+// derives from the user agent of the requests
+func FieldManagerFromUserAgent(userAgent string) string {
+	if i := strings.Index(userAgent, "/"); i >= 0 {
+		userAgent = userAgent[:i]
+	}
+	if userAgent == "" {
+		return DefaultFieldManager
+	}
+	return userAgent
+}
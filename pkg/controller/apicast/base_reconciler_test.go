@@ -0,0 +1,13 @@
+package apicast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldManagerFromUserAgent(t *testing.T) {
+	assert.Equal(t, "apicast-operator", FieldManagerFromUserAgent(UserAgent("apicast-operator")))
+	assert.Equal(t, "my-operator", FieldManagerFromUserAgent("my-operator/v1.0.0 (linux/amd64)"))
+	assert.Equal(t, DefaultFieldManager, FieldManagerFromUserAgent(""))
+}
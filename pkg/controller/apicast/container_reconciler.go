@@ -0,0 +1,123 @@
+package apicast
+
+import (
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ReconcileSidecarContainers reconciles the sidecar containers managed by
+// the operator. They are identified by name. Containers whose name is not
+// in managedNames are left untouched
+func ReconcileSidecarContainers(existing *[]v1.Container, desired []v1.Container, managedNames []string) bool {
+	updated := false
+
+	desiredNames := map[string]bool{}
+	for _, desiredContainer := range desired {
+		desiredNames[desiredContainer.Name] = true
+	}
+
+	containers := []v1.Container{}
+	for _, existingContainer := range *existing {
+		if isManagedContainer(existingContainer.Name, managedNames) && !desiredNames[existingContainer.Name] {
+			updated = true
+			continue
+		}
+		containers = append(containers, existingContainer)
+	}
+
+	for _, desiredContainer := range desired {
+		idx := findContainer(containers, desiredContainer.Name)
+		if idx < 0 {
+			containers = append(containers, desiredContainer)
+			updated = true
+			continue
+		}
+		if reconcileContainer(&containers[idx], desiredContainer) {
+			updated = true
+		}
+	}
+
+	if updated {
+		*existing = containers
+	}
+
+	return updated
+}
+
+// ReconcileInitContainers reconciles the init containers of the pod. As they
+// run in order, the existing ones are replaced when the names or the order
+// do not match the desired ones
+func ReconcileInitContainers(existing *[]v1.Container, desired []v1.Container) bool {
+	if len(*existing) != len(desired) {
+		*existing = desired
+		return true
+	}
+
+	for idx := range desired {
+		if (*existing)[idx].Name != desired[idx].Name {
+			*existing = desired
+			return true
+		}
+	}
+
+	updated := false
+	for idx := range desired {
+		if reconcileContainer(&(*existing)[idx], desired[idx]) {
+			updated = true
+		}
+	}
+
+	return updated
+}
+
+// reconcileContainer reconciles the container fields set by the operator.
+// The rest of the fields are defaulted by the API server so they are not
+// compared
+func reconcileContainer(existing *v1.Container, desired v1.Container) bool {
+	updated := false
+
+	if existing.Image != desired.Image {
+		existing.Image = desired.Image
+		updated = true
+	}
+
+	if !reflect.DeepEqual(existing.Command, desired.Command) {
+		existing.Command = desired.Command
+		updated = true
+	}
+
+	if !reflect.DeepEqual(existing.Args, desired.Args) {
+		existing.Args = desired.Args
+		updated = true
+	}
+
+	if !reflect.DeepEqual(existing.VolumeMounts, desired.VolumeMounts) {
+		existing.VolumeMounts = desired.VolumeMounts
+		updated = true
+	}
+
+	if ReconcileEnvVar(&existing.Env, desired.Env) {
+		updated = true
+	}
+
+	return updated
+}
+
+func findContainer(containers []v1.Container, name string) int {
+	for idx, container := range containers {
+		if container.Name == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+func isManagedContainer(name string, managedNames []string) bool {
+	for _, managedName := range managedNames {
+		if name == managedName {
+			return true
+		}
+	}
+	return false
+}
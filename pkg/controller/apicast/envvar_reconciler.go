@@ -7,7 +7,10 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
-// ReconcileEnvVar reconciles environment var lists
+// ReconcileEnvVar reconciles environment var lists. All the environment vars
+// of the container are set by the operator, so the existing list is replaced
+// by the desired one when they differ. This way the vars of the cleared
+// optional fields are removed too
 func ReconcileEnvVar(existing *[]v1.EnvVar, desired []v1.EnvVar) bool {
 	if *existing == nil {
 		*existing = []v1.EnvVar{}
@@ -0,0 +1,96 @@
+package apicast
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ReconcileResultSuccess      = "success"
+	ReconcileResultRequeue      = "requeue"
+	ReconcileResultRequeueAfter = "requeue_after"
+	ReconcileResultError        = "error"
+)
+
+const (
+	// ReconcileErrorTransient is a temporary error of the API server, i.e.
+	// throttling or a conflict, retried after a backoff delay
+	ReconcileErrorTransient = "transient"
+	// ReconcileErrorSecretUnresolved is a referenced secret or key not found
+	ReconcileErrorSecretUnresolved = "secret_unresolved"
+	// ReconcileErrorInvalid is an APIcast resource or a referenced secret
+	// failing validation
+	ReconcileErrorInvalid = "invalid"
+	// ReconcileErrorAPI is any other error returned by the API server
+	ReconcileErrorAPI = "api_error"
+)
+
+var (
+	// reconcileDuration is the duration of the reconciliations of the APIcast
+	// resources by result
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "apicast_operator_reconcile_duration_seconds",
+		Help: "Duration of the reconciliations of the APIcast resources",
+	}, []string{"result"})
+
+	// reconcileTotal is the number of reconciliations of each APIcast
+	// resource by result
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "apicast_operator_reconcile_total",
+		Help: "Number of reconciliations of each APIcast resource",
+	}, []string{"namespace", "name", "result"})
+
+	// reconcileErrors is the number of failed reconciliations of the APIcast
+	// resources by reason
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "apicast_operator_reconcile_errors_total",
+		Help: "Number of failed reconciliations of the APIcast resources by reason",
+	}, []string{"reason"})
+)
+
+func init() {
+	// The controller-runtime registry is served in the metrics port of the
+	// manager
+	metrics.Registry.MustRegister(reconcileDuration, reconcileTotal, reconcileErrors)
+}
+
+// recordReconcileMetrics records the duration and the outcome of the
+// reconciliation of an APIcast resource
+func recordReconcileMetrics(key types.NamespacedName, duration time.Duration, result reconcile.Result, err error) {
+	resultLabel := reconcileResultLabel(result, err)
+	reconcileDuration.WithLabelValues(resultLabel).Observe(duration.Seconds())
+	reconcileTotal.WithLabelValues(key.Namespace, key.Name, resultLabel).Inc()
+	if err != nil {
+		reconcileErrors.WithLabelValues(reconcileErrorReason(err)).Inc()
+	}
+}
+
+func reconcileResultLabel(result reconcile.Result, err error) string {
+	switch {
+	case err != nil:
+		return ReconcileResultError
+	case result.RequeueAfter > 0:
+		return ReconcileResultRequeueAfter
+	case result.Requeue:
+		return ReconcileResultRequeue
+	default:
+		return ReconcileResultSuccess
+	}
+}
+
+func reconcileErrorReason(err error) string {
+	if _, ok := err.(*secretResolutionError); ok {
+		return ReconcileErrorSecretUnresolved
+	}
+	if isTransientError(err) {
+		return ReconcileErrorTransient
+	}
+	if isValidationError(err) {
+		return ReconcileErrorInvalid
+	}
+	return ReconcileErrorAPI
+}
@@ -0,0 +1,42 @@
+package apicast
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcileErrorReason(t *testing.T) {
+	resource := schema.GroupResource{Resource: "secrets"}
+	secretErr := &secretResolutionError{Field: "EmbeddedConfigurationSecretRef", Name: "apicast-config", err: errors.NewNotFound(resource, "apicast-config")}
+
+	assert.Equal(t, ReconcileErrorSecretUnresolved, reconcileErrorReason(secretErr))
+	assert.Equal(t, ReconcileErrorTransient, reconcileErrorReason(errors.NewTooManyRequests("throttled", 1)))
+	assert.Equal(t, ReconcileErrorInvalid, reconcileErrorReason(validationErrorf("Field 'Name' not specified")))
+	assert.Equal(t, ReconcileErrorAPI, reconcileErrorReason(errors.NewForbidden(resource, "apicast-config", nil)))
+	// Errors not raised by the validation, i.e. network errors, are not
+	// reported as an invalid APIcast
+	assert.Equal(t, ReconcileErrorAPI, reconcileErrorReason(fmt.Errorf("dial tcp 10.0.0.1:443: connect: connection refused")))
+	assert.Equal(t, ReconcileErrorAPI, reconcileErrorReason(context.DeadlineExceeded))
+}
+
+func TestRecordReconcileMetrics(t *testing.T) {
+	key := types.NamespacedName{Name: "metrics-apicast", Namespace: "operator-unittest"}
+
+	recordReconcileMetrics(key, time.Second, reconcile.Result{}, nil)
+	recordReconcileMetrics(key, time.Second, reconcile.Result{Requeue: true}, nil)
+	recordReconcileMetrics(key, time.Second, reconcile.Result{}, errors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "apicast-config", nil))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(reconcileTotal.WithLabelValues(key.Namespace, key.Name, ReconcileResultSuccess)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(reconcileTotal.WithLabelValues(key.Namespace, key.Name, ReconcileResultRequeue)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(reconcileTotal.WithLabelValues(key.Namespace, key.Name, ReconcileResultError)))
+	assert.Equal(t, "requeue_after", reconcileResultLabel(reconcile.Result{RequeueAfter: time.Second}, nil))
+}
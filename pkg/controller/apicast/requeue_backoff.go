@@ -0,0 +1,76 @@
+package apicast
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// MinTransientErrorRequeueDelay is the delay before reconciling again an
+	// APIcast resource after its first transient error
+	MinTransientErrorRequeueDelay = 1 * time.Second
+	// MaxTransientErrorRequeueDelay is the maximum delay before reconciling
+	// again an APIcast resource after consecutive transient errors
+	MaxTransientErrorRequeueDelay = 5 * time.Minute
+)
+
+// isTransientError returns whether the error is caused by a temporary
+// condition of the API server, i.e. throttling or a conflict with a
+// concurrent update, that is expected to go away by retrying later
+func isTransientError(err error) bool {
+	return errors.IsTooManyRequests(err) ||
+		errors.IsConflict(err) ||
+		errors.IsServerTimeout(err) ||
+		errors.IsTimeout(err) ||
+		errors.IsServiceUnavailable(err)
+}
+
+// requeueBackoff tracks the consecutive transient errors of each APIcast
+// resource to compute an exponential requeue delay
+type requeueBackoff struct {
+	mutex    sync.Mutex
+	failures map[types.NamespacedName]uint
+}
+
+// next records a transient error of the APIcast resource and returns the
+// delay before reconciling it again. It doubles with every consecutive
+// error, and the delay suggested by the API server, if any, is respected
+func (b *requeueBackoff) next(key types.NamespacedName, err error) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.failures == nil {
+		b.failures = map[types.NamespacedName]uint{}
+	}
+
+	failures := b.failures[key]
+	b.failures[key] = failures + 1
+
+	delay := MaxTransientErrorRequeueDelay
+	if failures < 16 {
+		delay = MinTransientErrorRequeueDelay << failures
+	}
+	if delay > MaxTransientErrorRequeueDelay {
+		delay = MaxTransientErrorRequeueDelay
+	}
+
+	if seconds, ok := errors.SuggestsClientDelay(err); ok {
+		if suggested := time.Duration(seconds) * time.Second; suggested > delay {
+			delay = suggested
+		}
+	}
+
+	return delay
+}
+
+// reset forgets the transient errors of the APIcast resource once it has
+// been reconciled
+func (b *requeueBackoff) reset(key types.NamespacedName) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	delete(b.failures, key)
+}
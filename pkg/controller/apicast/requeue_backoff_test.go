@@ -0,0 +1,43 @@
+package apicast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestIsTransientError(t *testing.T) {
+	resource := schema.GroupResource{Resource: "secrets"}
+	assert.True(t, isTransientError(errors.NewTooManyRequests("throttled", 1)))
+	assert.True(t, isTransientError(errors.NewConflict(resource, "apicast-config", nil)))
+	assert.True(t, isTransientError(errors.NewServerTimeout(resource, "get", 1)))
+	assert.False(t, isTransientError(errors.NewNotFound(resource, "apicast-config")))
+	assert.False(t, isTransientError(errors.NewForbidden(resource, "apicast-config", nil)))
+}
+
+func TestRequeueBackoff(t *testing.T) {
+	backoff := requeueBackoff{}
+	key := types.NamespacedName{Name: "example-apicast", Namespace: "operator-unittest"}
+	otherKey := types.NamespacedName{Name: "other-apicast", Namespace: "operator-unittest"}
+	conflict := errors.NewConflict(schema.GroupResource{Resource: "secrets"}, "apicast-config", nil)
+
+	// The delay doubles with every consecutive error, up to the maximum
+	assert.Equal(t, 1*time.Second, backoff.next(key, conflict))
+	assert.Equal(t, 2*time.Second, backoff.next(key, conflict))
+	assert.Equal(t, 4*time.Second, backoff.next(key, conflict))
+	for i := 0; i < 20; i++ {
+		backoff.next(key, conflict)
+	}
+	assert.Equal(t, MaxTransientErrorRequeueDelay, backoff.next(key, conflict))
+
+	// The errors of each APIcast resource are tracked separately
+	assert.Equal(t, 1*time.Second, backoff.next(otherKey, conflict))
+
+	// The delay suggested by the API server is respected
+	backoff.reset(key)
+	assert.Equal(t, 30*time.Second, backoff.next(key, errors.NewTooManyRequests("throttled", 30)))
+}
@@ -0,0 +1,49 @@
+package apicast
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SecretNotFoundReason is the reason of the SecretResolved condition when
+	// a referenced secret does not exist
+	SecretNotFoundReason = "SecretNotFound"
+	// SecretKeyNotFoundReason is the reason of the SecretResolved condition
+	// when a referenced secret does not have a required key
+	SecretKeyNotFoundReason = "SecretKeyNotFound"
+)
+
+// secretResolutionError is returned when a secret referenced by the APIcast
+// resource cannot be found or misses a required key. The error message is
+// the one of the underlying error
+type secretResolutionError struct {
+	// Field of the APIcast spec referencing the secret
+	Field string
+	// Name of the secret
+	Name string
+	// Key missing in the secret. Empty when the secret was not found
+	Key string
+	err error
+}
+
+func (e *secretResolutionError) Error() string {
+	return e.err.Error()
+}
+
+// condition returns the SecretResolved condition describing the error
+func (e *secretResolutionError) condition() appsv1alpha1.APIcastCondition {
+	condition := appsv1alpha1.APIcastCondition{
+		Type:    appsv1alpha1.SecretResolvedConditionType,
+		Status:  v1.ConditionFalse,
+		Reason:  SecretNotFoundReason,
+		Message: fmt.Sprintf("Secret '%s' referenced in %s not found", e.Name, e.Field),
+	}
+	if e.Key != "" {
+		condition.Reason = SecretKeyNotFoundReason
+		condition.Message = fmt.Sprintf("Required key '%s' not found in secret '%s' referenced in %s", e.Key, e.Name, e.Field)
+	}
+	return condition
+}
@@ -0,0 +1,27 @@
+package apicast
+
+import (
+	"fmt"
+)
+
+// validationError is returned when the APIcast resource or the contents of
+// its referenced secrets are not valid, so reconciling it again fails the
+// same way until they are changed. The error message is the one of the
+// underlying error
+type validationError struct {
+	err error
+}
+
+func (e *validationError) Error() string {
+	return e.err.Error()
+}
+
+// newValidationError wraps the error as a validationError
+func newValidationError(err error) error {
+	return &validationError{err: err}
+}
+
+// validationErrorf returns a validationError with the formatted message
+func validationErrorf(format string, args ...interface{}) error {
+	return newValidationError(fmt.Errorf(format, args...))
+}
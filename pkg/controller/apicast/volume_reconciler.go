@@ -0,0 +1,53 @@
+package apicast
+
+import (
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ReconcileVolumes reconciles the pod volumes managed by the operator. They
+// are identified by name, and the volumes not managed by the operator, i.e.
+// injected by a service mesh, are left untouched in their position
+func ReconcileVolumes(existing *[]v1.Volume, desired []v1.Volume, isManaged func(string) bool) bool {
+	updated := false
+
+	desiredIdx := map[string]int{}
+	for idx, desiredVolume := range desired {
+		desiredIdx[desiredVolume.Name] = idx
+	}
+
+	volumes := []v1.Volume{}
+	found := map[string]bool{}
+	for _, existingVolume := range *existing {
+		idx, ok := desiredIdx[existingVolume.Name]
+		if !ok {
+			if isManaged(existingVolume.Name) {
+				updated = true
+				continue
+			}
+			volumes = append(volumes, existingVolume)
+			continue
+		}
+
+		found[existingVolume.Name] = true
+		if !reflect.DeepEqual(existingVolume, desired[idx]) {
+			existingVolume = desired[idx]
+			updated = true
+		}
+		volumes = append(volumes, existingVolume)
+	}
+
+	for _, desiredVolume := range desired {
+		if !found[desiredVolume.Name] {
+			volumes = append(volumes, desiredVolume)
+			updated = true
+		}
+	}
+
+	if updated {
+		*existing = volumes
+	}
+
+	return updated
+}
@@ -0,0 +1,10 @@
+package webhook
+
+import (
+	"github.com/3scale/apicast-operator/pkg/webhook/apicast"
+)
+
+func init() {
+	// AddToManagerFuncs is a list of functions to create webhooks and add them to the admission webhook server.
+	AddToManagerFuncs = append(AddToManagerFuncs, apicast.Add)
+}
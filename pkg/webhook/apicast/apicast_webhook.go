@@ -0,0 +1,204 @@
+package apicast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	appsv1alpha1 "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	crwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/builder"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+var log = logf.Log.WithName("webhook_apicast")
+
+// Add creates the mutating webhook that sets the defaults of the APIcast
+// resources and the validating webhook that rejects the invalid ones.
+// The failure policy is Ignore, as the controller applies the same defaults
+// and validation, reporting the errors in the status, so the APIcast
+// resources can still be changed while the operator is down
+func Add(mgr manager.Manager) ([]crwebhook.Webhook, error) {
+	failurePolicy := admissionregistrationv1beta1.Ignore
+
+	mutatingWebhook, err := builder.NewWebhookBuilder().
+		Name("mutating.apicasts.apps.3scale.net").
+		Mutating().
+		Path("/mutate-apicasts").
+		Operations(admissionregistrationv1beta1.Create, admissionregistrationv1beta1.Update).
+		ForType(&appsv1alpha1.APIcast{}).
+		FailurePolicy(failurePolicy).
+		WithManager(mgr).
+		Handlers(&apicastDefaulter{}).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	validatingWebhook, err := builder.NewWebhookBuilder().
+		Name("validating.apicasts.apps.3scale.net").
+		Validating().
+		Path("/validate-apicasts").
+		Operations(admissionregistrationv1beta1.Create, admissionregistrationv1beta1.Update).
+		ForType(&appsv1alpha1.APIcast{}).
+		FailurePolicy(failurePolicy).
+		WithManager(mgr).
+		Handlers(&apicastValidator{}).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return []crwebhook.Webhook{mutatingWebhook, validatingWebhook}, nil
+}
+
+// apicastDefaulter sets the defaults of the APIcast resources on admission
+type apicastDefaulter struct {
+	decoder types.Decoder
+}
+
+func (h *apicastDefaulter) Handle(ctx context.Context, req types.Request) types.Response {
+	apicast := &appsv1alpha1.APIcast{}
+	err := h.decoder.Decode(req, apicast)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	defaulted := apicast.DeepCopy()
+	if defaulted.Spec.SetDefaults() {
+		log.Info("Setting defaults", "Name", apicast.Name, "Namespace", apicast.Namespace)
+	}
+	return admission.PatchResponse(apicast, defaulted)
+}
+
+func (h *apicastDefaulter) InjectDecoder(d types.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// apicastValidator rejects the APIcast resources with an invalid spec on
+// admission, and the ones referencing admin portal credentials secrets of
+// other namespaces the requesting user cannot read
+type apicastValidator struct {
+	client  client.Client
+	decoder types.Decoder
+}
+
+func (h *apicastValidator) Handle(ctx context.Context, req types.Request) types.Response {
+	apicast := &appsv1alpha1.APIcast{}
+	err := h.decoder.Decode(req, apicast)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	oldAPIcast, err := decodeOldAPIcast(req)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	// The spec is only validated when it changes, so the metadata of the
+	// APIcast resources admitted before a validation was added can still be
+	// updated, i.e. by the operator or to pause them
+	if oldAPIcast == nil || !isSpecUnchanged(oldAPIcast, apicast) {
+		if validationErrs := apicast.Validate(); len(validationErrs) > 0 {
+			return admission.ValidationResponse(false, validationErrs.ToAggregate().Error())
+		}
+	}
+
+	allowed, reason, err := h.canReadAdminPortalCredentials(ctx, req, apicast, oldAPIcast)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	if !allowed {
+		return admission.ValidationResponse(false, reason)
+	}
+	return admission.ValidationResponse(true, "")
+}
+
+// decodeOldAPIcast returns the APIcast resource before an update, or nil for
+// the other operations
+func decodeOldAPIcast(req types.Request) (*appsv1alpha1.APIcast, error) {
+	if len(req.AdmissionRequest.OldObject.Raw) == 0 {
+		return nil, nil
+	}
+	oldAPIcast := &appsv1alpha1.APIcast{}
+	err := json.Unmarshal(req.AdmissionRequest.OldObject.Raw, oldAPIcast)
+	if err != nil {
+		return nil, err
+	}
+	return oldAPIcast, nil
+}
+
+// isSpecUnchanged returns whether an update leaves the spec unchanged. The
+// defaults are applied to both, as the defaulting webhook sets them in the
+// updated resource
+func isSpecUnchanged(oldAPIcast, apicast *appsv1alpha1.APIcast) bool {
+	oldSpec := oldAPIcast.Spec.DeepCopy()
+	oldSpec.SetDefaults()
+	spec := apicast.Spec.DeepCopy()
+	spec.SetDefaults()
+	return reflect.DeepEqual(oldSpec, spec)
+}
+
+// canReadAdminPortalCredentials checks with a SubjectAccessReview that the
+// requesting user can read the admin portal credentials secret referenced in
+// another namespace, as the operator copies it to the namespace of the
+// APIcast resource. It is only checked when the reference changes, so other
+// users can still update the APIcast resource
+func (h *apicastValidator) canReadAdminPortalCredentials(ctx context.Context, req types.Request, apicast, oldAPIcast *appsv1alpha1.APIcast) (bool, string, error) {
+	ref := apicast.Spec.AdminPortalCredentialsRef
+	if ref == nil || ref.Namespace == "" || ref.Namespace == apicast.Namespace {
+		return true, "", nil
+	}
+
+	if oldAPIcast != nil && reflect.DeepEqual(oldAPIcast.Spec.AdminPortalCredentialsRef, ref) {
+		return true, "", nil
+	}
+
+	userInfo := req.AdmissionRequest.UserInfo
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range userInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			Groups: userInfo.Groups,
+			UID:    userInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: ref.Namespace,
+				Verb:      "get",
+				Resource:  "secrets",
+				Name:      ref.Name,
+			},
+		},
+	}
+	err := h.client.Create(ctx, review)
+	if err != nil {
+		return false, "", err
+	}
+
+	if !review.Status.Allowed {
+		return false, fmt.Sprintf("user %q cannot get the secret %s/%s referenced by spec.adminPortalCredentialsRef", userInfo.Username, ref.Namespace, ref.Name), nil
+	}
+	return true, "", nil
+}
+
+func (h *apicastValidator) InjectClient(c client.Client) error {
+	h.client = c
+	return nil
+}
+
+func (h *apicastValidator) InjectDecoder(d types.Decoder) error {
+	h.decoder = d
+	return nil
+}
@@ -0,0 +1,263 @@
+package apicast
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/3scale/apicast-operator/pkg/apis"
+	appsv1alpha1 "github.com/3scale/apicast-operator/pkg/apis/apps/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// subjectAccessReviewClient allows the SubjectAccessReviews of the given
+// user, and counts them
+type subjectAccessReviewClient struct {
+	client.Client
+	allowedUser string
+	reviews     int
+}
+
+func (c *subjectAccessReviewClient) Create(ctx context.Context, obj runtime.Object) error {
+	review := obj.(*authorizationv1.SubjectAccessReview)
+	c.reviews++
+	review.Status.Allowed = review.Spec.User == c.allowedUser
+	return nil
+}
+
+func newTestAPIcast() *appsv1alpha1.APIcast {
+	return &appsv1alpha1.APIcast{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "APIcast",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-apicast",
+			Namespace: "operator-unittest",
+		},
+		Spec: appsv1alpha1.APIcastSpec{
+			EmbeddedConfigurationSecretRef: &v1.LocalObjectReference{Name: "apicast-config"},
+		},
+	}
+}
+
+func newTestRequest(t *testing.T, username string, obj, oldObj *appsv1alpha1.APIcast) types.Request {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := &admissionv1beta1.AdmissionRequest{
+		Operation: admissionv1beta1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+		UserInfo:  authenticationv1.UserInfo{Username: username},
+	}
+	if oldObj != nil {
+		oldRaw, err := json.Marshal(oldObj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		request.Operation = admissionv1beta1.Update
+		request.OldObject = runtime.RawExtension{Raw: oldRaw}
+	}
+	return types.Request{AdmissionRequest: request}
+}
+
+func newTestDecoder(t *testing.T) types.Decoder {
+	s := scheme.Scheme
+	err := apis.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoder, err := admission.NewDecoder(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoder
+}
+
+func TestValidateCrossNamespaceAdminPortalCredentials(t *testing.T) {
+	crossNamespaceRef := &v1.SecretReference{Name: "central-credentials", Namespace: "3scale-credentials"}
+	withRef := func(ref *v1.SecretReference) *appsv1alpha1.APIcast {
+		apicast := newTestAPIcast()
+		apicast.Spec.EmbeddedConfigurationSecretRef = nil
+		apicast.Spec.AdminPortalCredentialsRef = ref
+		return apicast
+	}
+	cases := []struct {
+		name     string
+		username string
+		obj      *appsv1alpha1.APIcast
+		oldObj   *appsv1alpha1.APIcast
+		allowed  bool
+		reviews  int
+	}{
+		{"secret of the same namespace", "developer", withRef(&v1.SecretReference{Name: "credentials"}), nil, true, 0},
+		{"user allowed to read the secret", "admin", withRef(crossNamespaceRef), nil, true, 1},
+		{"user not allowed to read the secret", "developer", withRef(crossNamespaceRef), nil, false, 1},
+		{"reference not changed", "developer", withRef(crossNamespaceRef), withRef(crossNamespaceRef), true, 0},
+		{"reference changed", "developer", withRef(crossNamespaceRef), withRef(&v1.SecretReference{Name: "credentials"}), false, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reviewClient := &subjectAccessReviewClient{allowedUser: "admin"}
+			validator := &apicastValidator{}
+			if err := validator.InjectClient(reviewClient); err != nil {
+				t.Fatal(err)
+			}
+			if err := validator.InjectDecoder(newTestDecoder(t)); err != nil {
+				t.Fatal(err)
+			}
+
+			response := validator.Handle(context.TODO(), newTestRequest(t, tc.username, tc.obj, tc.oldObj))
+
+			assert.Equal(t, tc.allowed, response.Response.Allowed)
+			assert.Equal(t, tc.reviews, reviewClient.reviews)
+		})
+	}
+}
+
+func TestDefaultAPIcast(t *testing.T) {
+	defaulted := newTestAPIcast()
+	defaulted.Spec.SetDefaults()
+	cases := []struct {
+		name            string
+		obj             *appsv1alpha1.APIcast
+		expectedPatches bool
+	}{
+		{"without defaults", newTestAPIcast(), true},
+		{"with defaults", defaulted, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defaulter := &apicastDefaulter{}
+			if err := defaulter.InjectDecoder(newTestDecoder(t)); err != nil {
+				t.Fatal(err)
+			}
+
+			response := defaulter.Handle(context.TODO(), newTestRequest(t, "developer", tc.obj, nil))
+
+			assert.True(t, response.Response.Allowed)
+			assert.Equal(t, tc.expectedPatches, len(response.Patches) > 0)
+			for _, patch := range response.Patches {
+				assert.Contains(t, patch.Path, "/spec/")
+			}
+		})
+	}
+}
+
+func TestValidateAPIcastSpec(t *testing.T) {
+	invalidLogLevel := "verbose"
+	cases := []struct {
+		name    string
+		mutate  func(*appsv1alpha1.APIcast)
+		allowed bool
+		reason  string
+	}{
+		{"valid", func(a *appsv1alpha1.APIcast) {}, true, ""},
+		{"invalid log level", func(a *appsv1alpha1.APIcast) {
+			a.Spec.LogLevel = &invalidLogLevel
+		}, false, "spec.logLevel"},
+		{"without configuration source", func(a *appsv1alpha1.APIcast) {
+			a.Spec.EmbeddedConfigurationSecretRef = nil
+		}, false, "spec.embeddedConfigurationSecretRef"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := &apicastValidator{}
+			if err := validator.InjectClient(&subjectAccessReviewClient{}); err != nil {
+				t.Fatal(err)
+			}
+			if err := validator.InjectDecoder(newTestDecoder(t)); err != nil {
+				t.Fatal(err)
+			}
+			apicast := newTestAPIcast()
+			tc.mutate(apicast)
+
+			response := validator.Handle(context.TODO(), newTestRequest(t, "developer", apicast, nil))
+
+			assert.Equal(t, tc.allowed, response.Response.Allowed)
+			if !tc.allowed {
+				assert.Contains(t, string(response.Response.Result.Reason), tc.reason)
+			}
+		})
+	}
+}
+
+func TestValidateAPIcastSpecUpdate(t *testing.T) {
+	invalidLogLevel := "verbose"
+	withInvalidLogLevel := func(a *appsv1alpha1.APIcast) {
+		a.Spec.LogLevel = &invalidLogLevel
+	}
+	cases := []struct {
+		name      string
+		oldMutate func(*appsv1alpha1.APIcast)
+		mutate    func(*appsv1alpha1.APIcast)
+		allowed   bool
+	}{
+		{"invalid spec not changed", withInvalidLogLevel, func(a *appsv1alpha1.APIcast) {
+			withInvalidLogLevel(a)
+			a.Annotations = map[string]string{"apicast.apps.3scale.net/operator-version": "0.0.1"}
+		}, true},
+		{"invalid spec defaulted", withInvalidLogLevel, func(a *appsv1alpha1.APIcast) {
+			withInvalidLogLevel(a)
+			a.Spec.SetDefaults()
+		}, true},
+		{"invalid spec changed", withInvalidLogLevel, func(a *appsv1alpha1.APIcast) {
+			withInvalidLogLevel(a)
+			replicas := int64(2)
+			a.Spec.Replicas = &replicas
+		}, false},
+		{"error introduced", func(a *appsv1alpha1.APIcast) {}, withInvalidLogLevel, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := &apicastValidator{}
+			if err := validator.InjectClient(&subjectAccessReviewClient{}); err != nil {
+				t.Fatal(err)
+			}
+			if err := validator.InjectDecoder(newTestDecoder(t)); err != nil {
+				t.Fatal(err)
+			}
+			oldAPIcast := newTestAPIcast()
+			tc.oldMutate(oldAPIcast)
+			apicast := newTestAPIcast()
+			tc.mutate(apicast)
+
+			response := validator.Handle(context.TODO(), newTestRequest(t, "developer", apicast, oldAPIcast))
+
+			assert.Equal(t, tc.allowed, response.Response.Allowed)
+		})
+	}
+}
+
+func TestDecodeInvalidAPIcast(t *testing.T) {
+	request := types.Request{AdmissionRequest: &admissionv1beta1.AdmissionRequest{
+		Operation: admissionv1beta1.Create,
+		Object:    runtime.RawExtension{Raw: []byte(`{"spec":`)},
+	}}
+	defaulter := &apicastDefaulter{}
+	validator := &apicastValidator{}
+	if err := defaulter.InjectDecoder(newTestDecoder(t)); err != nil {
+		t.Fatal(err)
+	}
+	if err := validator.InjectDecoder(newTestDecoder(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, handler := range []admission.Handler{defaulter, validator} {
+		response := handler.Handle(context.TODO(), request)
+		assert.False(t, response.Response.Allowed)
+		assert.Equal(t, int32(http.StatusBadRequest), response.Response.Result.Code)
+	}
+}
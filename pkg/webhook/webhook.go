@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	crwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	// ServerName is the name of the admission webhook server
+	ServerName = "apicast-operator-admission-server"
+	// ServiceName is the name of the Service fronting the admission webhook
+	// server, created in the namespace of the operator
+	ServiceName = "apicast-operator-webhook"
+	// MutatingWebhookConfigName is the name of the MutatingWebhookConfiguration
+	// installed by the admission webhook server
+	MutatingWebhookConfigName = "apicast-operator-mutating-webhook-configuration"
+	// ValidatingWebhookConfigName is the name of the
+	// ValidatingWebhookConfiguration installed by the admission webhook server
+	ValidatingWebhookConfigName = "apicast-operator-validating-webhook-configuration"
+)
+
+// Options are the options of the admission webhook server
+type Options struct {
+	// Port the server listens on
+	Port int32
+	// CertDir is the directory where the server certificate is provisioned
+	CertDir string
+	// Namespace of the operator, where the Service is created
+	Namespace string
+	// ServiceSelector selects the operator pods from the Service
+	ServiceSelector map[string]string
+}
+
+// AddToManagerFuncs is a list of functions to create the webhooks served by
+// the admission webhook server
+var AddToManagerFuncs []func(manager.Manager) ([]crwebhook.Webhook, error)
+
+// AddToManager adds the admission webhook server, with all the webhooks, to
+// the Manager. The server provisions its certificate and installs the
+// webhook configurations and the Service when it is started
+func AddToManager(m manager.Manager, options Options) error {
+	webhooks := []crwebhook.Webhook{}
+	for _, f := range AddToManagerFuncs {
+		managerWebhooks, err := f(m)
+		if err != nil {
+			return err
+		}
+		webhooks = append(webhooks, managerWebhooks...)
+	}
+
+	server, err := crwebhook.NewServer(ServerName, m, crwebhook.ServerOptions{
+		Port:    options.Port,
+		CertDir: options.CertDir,
+		BootstrapOptions: &crwebhook.BootstrapOptions{
+			MutatingWebhookConfigName:   MutatingWebhookConfigName,
+			ValidatingWebhookConfigName: ValidatingWebhookConfigName,
+			Service: &crwebhook.Service{
+				Name:      ServiceName,
+				Namespace: options.Namespace,
+				Selectors: options.ServiceSelector,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return server.Register(webhooks...)
+}